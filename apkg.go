@@ -0,0 +1,348 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// An .apkg file is a zip holding the deck's SQLite collection. Only the
+// uncompressed collections are supported: collection.anki21b, which newer
+// Anki versions write unless "Support older Anki versions" is ticked, is
+// zstd-compressed.
+var apkgCollections = []string{"collection.anki21", "collection.anki2"}
+
+// apkgNote is one Anki note: its field names and values, in order.
+type apkgNote struct {
+	Names  []string
+	Fields []string
+}
+
+// readApkg returns the notes of an .apkg deck.
+func readApkg(path string) ([]apkgNote, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, &ValidationError{Field: "file", Message: "not a valid .apkg file"}
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	if files["collection.anki21b"] != nil && files["collection.anki21"] == nil {
+		return nil, &ValidationError{Field: "file", Message: `this deck needs a newer reader; export it again with "Support older Anki versions" ticked, or as Notes in Plain Text`}
+	}
+	for _, name := range apkgCollections {
+		f := files[name]
+		if f == nil {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		return readAnkiCollection(data)
+	}
+	return nil, &ValidationError{Field: "file", Message: "the .apkg file has no collection"}
+}
+
+// readAnkiCollection reads the notes table of an Anki collection database,
+// naming fields from the note types in the col table when it can.
+func readAnkiCollection(data []byte) ([]apkgNote, error) {
+	db, err := openSQLite(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// col.models (column 9) maps note type IDs to their field names
+	fieldNames := make(map[string][]string)
+	if rows, err := db.table("col"); err == nil && len(rows) > 0 && len(rows[0]) > 9 {
+		var models map[string]struct {
+			Flds []struct {
+				Name string `json:"name"`
+			} `json:"flds"`
+		}
+		if s, ok := rows[0][9].(string); ok && json.Unmarshal([]byte(s), &models) == nil {
+			for id, m := range models {
+				for _, f := range m.Flds {
+					fieldNames[id] = append(fieldNames[id], f.Name)
+				}
+			}
+		}
+	}
+
+	rows, err := db.table("notes")
+	if err != nil {
+		return nil, err
+	}
+	notes := make([]apkgNote, 0, len(rows))
+	for _, row := range rows {
+		// notes: id, guid, mid, mod, usn, tags, flds, ...
+		if len(row) < 7 {
+			continue
+		}
+		flds, _ := row[6].(string)
+		mid := fmt.Sprint(row[2])
+		notes = append(notes, apkgNote{Names: fieldNames[mid], Fields: strings.Split(flds, "\x1f")})
+	}
+	return notes, nil
+}
+
+// sqliteDB reads table rows out of a SQLite database file held in memory.
+// It supports what Anki collections use: rowid tables, overflow pages and
+// UTF-8 text. Indexes, WITHOUT ROWID tables and WAL files are not read.
+type sqliteDB struct {
+	data     []byte
+	pageSize int
+	usable   int
+}
+
+var errSQLite = &ValidationError{Field: "file", Message: "the deck's database is damaged or not SQLite"}
+
+func openSQLite(data []byte) (*sqliteDB, error) {
+	if len(data) < 100 || string(data[:16]) != "SQLite format 3\x00" {
+		return nil, errSQLite
+	}
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	// Text encoding 1 is UTF-8; 0 means the database has no text yet
+	if pageSize < 512 || binary.BigEndian.Uint32(data[56:60]) > 1 {
+		return nil, errSQLite
+	}
+	return &sqliteDB{data: data, pageSize: pageSize, usable: pageSize - int(data[20])}, nil
+}
+
+func (db *sqliteDB) page(n int) ([]byte, error) {
+	start := (n - 1) * db.pageSize
+	if n < 1 || start+db.pageSize > len(db.data) {
+		return nil, errSQLite
+	}
+	return db.data[start : start+db.pageSize], nil
+}
+
+// table returns the rows of the named table, each as its column values.
+func (db *sqliteDB) table(name string) ([][]interface{}, error) {
+	// sqlite_master, rooted at page 1: type, name, tbl_name, rootpage, sql
+	master, err := db.scan(1)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range master {
+		if len(row) >= 4 && row[0] == "table" && row[1] == name {
+			root, ok := row[3].(int64)
+			if !ok {
+				return nil, errSQLite
+			}
+			return db.scan(int(root))
+		}
+	}
+	return nil, fmt.Errorf("table %q not found", name)
+}
+
+// scan walks a table b-tree from its root page and decodes every record.
+func (db *sqliteDB) scan(root int) ([][]interface{}, error) {
+	var rows [][]interface{}
+	visited := make(map[int]bool) // A damaged file can link pages in a cycle
+	var walk func(n, depth int) error
+	walk = func(n, depth int) error {
+		if depth > 64 || visited[n] {
+			return errSQLite
+		}
+		visited[n] = true
+		page, err := db.page(n)
+		if err != nil {
+			return err
+		}
+		hdr := 0
+		if n == 1 {
+			hdr = 100
+		}
+		if hdr+8 > len(page) {
+			return errSQLite
+		}
+		kind := page[hdr]
+		cells := int(binary.BigEndian.Uint16(page[hdr+3:]))
+		if hdr+12+2*cells > len(page) {
+			return errSQLite
+		}
+		switch kind {
+		case 0x05: // Interior table page
+			ptrs := hdr + 12
+			for i := 0; i < cells; i++ {
+				off := int(binary.BigEndian.Uint16(page[ptrs+2*i:]))
+				if off+4 > len(page) {
+					return errSQLite
+				}
+				if err := walk(int(binary.BigEndian.Uint32(page[off:])), depth+1); err != nil {
+					return err
+				}
+			}
+			return walk(int(binary.BigEndian.Uint32(page[hdr+8:])), depth+1)
+		case 0x0D: // Leaf table page
+			ptrs := hdr + 8
+			for i := 0; i < cells; i++ {
+				off := int(binary.BigEndian.Uint16(page[ptrs+2*i:]))
+				payload, err := db.cellPayload(page, off)
+				if err != nil {
+					return err
+				}
+				row, err := decodeRecord(payload)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, row)
+			}
+			return nil
+		}
+		return errSQLite
+	}
+	if err := walk(root, 0); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// cellPayload returns the record of a leaf table cell, following its
+// overflow pages if it does not fit on the page.
+func (db *sqliteDB) cellPayload(page []byte, off int) ([]byte, error) {
+	if off >= len(page) {
+		return nil, errSQLite
+	}
+	size, n := sqliteVarint(page[off:])
+	off += n
+	_, m := sqliteVarint(page[off:]) // rowid
+	off += m
+	if n == 0 || m == 0 || size > uint64(len(db.data)) {
+		return nil, errSQLite
+	}
+
+	total := int(size) // Bounded by the file above, so it cannot overflow
+	maxLocal := db.usable - 35
+	local := total
+	if total > maxLocal {
+		minLocal := (db.usable-12)*32/255 - 23
+		local = minLocal + (total-minLocal)%(db.usable-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if off+local > len(page) {
+		return nil, errSQLite
+	}
+	payload := append([]byte(nil), page[off:off+local]...)
+	if local == total {
+		return payload, nil
+	}
+
+	if off+local+4 > len(page) {
+		return nil, errSQLite
+	}
+	next := int(binary.BigEndian.Uint32(page[off+local:]))
+	for len(payload) < total {
+		overflow, err := db.page(next)
+		if err != nil {
+			return nil, err
+		}
+		chunk := overflow[4:db.usable]
+		if rest := total - len(payload); len(chunk) > rest {
+			chunk = chunk[:rest]
+		}
+		payload = append(payload, chunk...)
+		next = int(binary.BigEndian.Uint32(overflow))
+	}
+	return payload, nil
+}
+
+// decodeRecord decodes a SQLite record into nil, int64, float64, string
+// or []byte values.
+func decodeRecord(rec []byte) ([]interface{}, error) {
+	hdrSize, n := sqliteVarint(rec)
+	if n == 0 || hdrSize > uint64(len(rec)) || int(hdrSize) < n {
+		return nil, errSQLite
+	}
+	var types []uint64
+	for pos := n; pos < int(hdrSize); {
+		t, n := sqliteVarint(rec[pos:])
+		if n == 0 {
+			return nil, errSQLite
+		}
+		types = append(types, t)
+		pos += n
+	}
+
+	body := rec[hdrSize:]
+	values := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		var size uint64
+		switch {
+		case t == 0 || t == 8 || t == 9:
+			size = 0
+		case t <= 4:
+			size = t
+		case t == 5:
+			size = 6
+		case t == 6 || t == 7:
+			size = 8
+		case t >= 12:
+			size = (t - 12) / 2
+		default:
+			return nil, errSQLite
+		}
+		if size > uint64(len(body)) {
+			return nil, errSQLite
+		}
+		v := body[:size]
+		body = body[size:]
+
+		switch {
+		case t == 0:
+			values = append(values, nil)
+		case t == 8:
+			values = append(values, int64(0))
+		case t == 9:
+			values = append(values, int64(1))
+		case t == 7:
+			values = append(values, math.Float64frombits(binary.BigEndian.Uint64(v)))
+		case t <= 6:
+			// Big-endian two's complement of 1 to 8 bytes
+			x := int64(int8(v[0]))
+			for _, b := range v[1:] {
+				x = x<<8 | int64(b)
+			}
+			values = append(values, x)
+		case t%2 == 1:
+			values = append(values, string(v))
+		default:
+			values = append(values, append([]byte(nil), v...))
+		}
+	}
+	return values, nil
+}
+
+// sqliteVarint decodes a SQLite big-endian varint, returning its length,
+// or 0 if b is too short.
+func sqliteVarint(b []byte) (uint64, int) {
+	var x uint64
+	for i := 0; i < 9 && i < len(b); i++ {
+		if i == 8 {
+			return x<<8 | uint64(b[i]), 9
+		}
+		x = x<<7 | uint64(b[i]&0x7f)
+		if b[i] < 0x80 {
+			return x, i + 1
+		}
+	}
+	return 0, 0
+}
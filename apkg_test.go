@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The decks in testdata were exported with Python's sqlite3 module: a
+// three-note deck, a 600-note deck with 1 KiB pages so the notes table
+// needs interior pages, and a deck whose first note spills onto overflow
+// pages.
+
+func TestReadApkg(t *testing.T) {
+	names := []string{"Word", "Meaning", "Example"}
+	tests := []struct {
+		file  string
+		notes int
+		first []string
+		last  []string
+	}{
+		{"small.apkg", 3,
+			[]string{"mitigate", "make less severe", "Trees mitigate flooding."},
+			[]string{"albeit", "although", "It worked, albeit slowly."}},
+		{"multipage.apkg", 600,
+			[]string{"word0000", "meaning of word 0", "Example sentence number 0."},
+			[]string{"word0599", "meaning of word 599", "Example sentence number 599."}},
+		{"overflow.apkg", 2,
+			[]string{"verbose", "using more words than needed", strings.TrimSpace(strings.Repeat("This example sentence is long enough to spill onto overflow pages. ", 150))},
+			[]string{"concise", "brief but comprehensive", "Keep it concise."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			notes, err := readApkg(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(notes) != tt.notes {
+				t.Fatalf("got %d notes, want %d", len(notes), tt.notes)
+			}
+			for i, want := range map[int][]string{0: tt.first, len(notes) - 1: tt.last} {
+				if got := notes[i]; strings.Join(got.Names, "|") != strings.Join(names, "|") || strings.Join(got.Fields, "|") != strings.Join(want, "|") {
+					t.Errorf("note %d = %q %q, want %q %q", i, got.Names, got.Fields, names, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadApkgNotADeck(t *testing.T) {
+	var verr *ValidationError
+	if _, err := readApkg(filepath.Join("testdata", "missing.apkg")); !errors.As(err, &verr) {
+		t.Errorf("got %v, want a ValidationError", err)
+	}
+}
+
+// Sizes and pointers in a damaged database must fail the import, not
+// panic or loop.
+func TestSQLiteDamaged(t *testing.T) {
+	db := &sqliteDB{data: make([]byte, 4*1024), pageSize: 1024, usable: 1024}
+
+	huge := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}
+	if _, err := db.cellPayload(huge, 0); err != errSQLite {
+		t.Errorf("cellPayload with a huge size: got %v", err)
+	}
+	if _, err := decodeRecord(huge); err != errSQLite {
+		t.Errorf("decodeRecord with a huge header: got %v", err)
+	}
+	if _, err := decodeRecord([]byte{0x02, 0x81, 0x01}); err != errSQLite {
+		t.Errorf("decodeRecord with a value past the record: got %v", err)
+	}
+
+	// Page 2 is an interior page whose only child is itself
+	page := db.data[1024:2048]
+	page[0] = 0x05
+	page[4] = 1   // One cell
+	page[13] = 20 // Cell at offset 20
+	page[11] = 2  // Right-most child: page 2
+	page[23] = 2  // Cell child: page 2
+	if _, err := db.scan(2); err != errSQLite {
+		t.Errorf("scan of a page cycle: got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"os/exec"
@@ -17,24 +18,41 @@ import (
 
 // App struct
 type App struct {
-	ctx                    context.Context
-	isPaused               bool
-	pauseCounter           int
-	currentCategory        string
-	currentTimeStr         string
-	isHUDScratchpadVisible bool
-	lastHUDNotes           string
-}
-
-// NewApp creates a new App application struct
-func NewApp() *App {
-	return &App{}
+	ctx            context.Context
+	state          *StateService
+	blobs          *BlobStore
+	briefings      *BriefingEngine
+	dictionary     *Dictionary
+	recoveryNotice string // Set when damaged data was restored from a backup
+}
+
+// NewApp creates a new App application struct backed by store, with
+// attachments kept in blobs, briefing messages rendered by briefings and
+// words looked up in dictionary
+func NewApp(store Store, blobs *BlobStore, briefings *BriefingEngine, dictionary *Dictionary) *App {
+	return &App{state: NewStateService(store), blobs: blobs, briefings: briefings, dictionary: dictionary}
+}
+
+// logError reports an error no caller can be given through the Wails
+// logger, or the standard logger before startup.
+func (a *App) logError(format string, args ...interface{}) {
+	if a.ctx == nil {
+		log.Printf(format, args...)
+		return
+	}
+	runtime.LogErrorf(a.ctx, format, args...)
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.state.SetEmitter(func(event string, data interface{}) {
+		runtime.EventsEmit(ctx, event, data)
+	})
+	if err := a.state.Do(a.blobs.externalizeAll); err != nil {
+		a.logError("Moving attachments out of old logs failed: %v", err)
+	}
 	a.startFocusEngine()
 	a.StartScheduler()
 
@@ -67,16 +85,26 @@ func (a *App) startup(ctx context.Context) {
 	go a.startHUDCommandListener()
 	go a.startHUDNotesWatcher()
 
+	if a.recoveryNotice != "" {
+		runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
+			Type:    runtime.WarningDialog,
+			Title:   "ENGRESS: Data Restored",
+			Message: a.recoveryNotice,
+		})
+	}
+
 	// Daily Alert Logic
-	state, _ := a.LoadState()
-	today := time.Now().Format("2006-01-02")
+	state, _ := a.loadState()
+	today := time.Now().In(state.UserProfile.location()).Format(dayLayout)
 
 	if state.UserProfile.IsSetupComplete && state.UserProfile.LastOpenDate != today {
 		briefing := a.GetEngressBriefing()
 
 		// Update last open date
-		state.UserProfile.LastOpenDate = today
-		a.SaveState(state)
+		a.state.UpdateProfile(func(p *UserProfile) error {
+			p.LastOpenDate = today
+			return nil
+		})
 
 		// Show intrusive alert
 		runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
@@ -94,71 +122,66 @@ func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, It's show time!", name)
 }
 
-func (a *App) LogSession(category string, reflection string, score float64, homework string, duration int, learnings string, content string, sourceURL string, screenshot string) {
-	state, _ := a.LoadState()
-	state.DailyLogs = append(state.DailyLogs, DailyLog{
+// LogSession records a finished session and returns its ID
+func (a *App) LogSession(input SessionInput) (string, error) {
+	state, _ := a.loadState()
+	if input.ExamType == "" {
+		input.ExamType = state.UserProfile.ExamType
+	}
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+	log := DailyLog{
 		ID:         fmt.Sprintf("%d", time.Now().UnixNano()),
-		Date:       time.Now().Format("2006-01-02"),
-		Duration:   duration,
-		Module:     category,
-		Reflection: reflection,
-		Score:      score,
-		Homework:   homework,
-		Learnings:  learnings,
-		Content:    content,
-		SourceURL:  sourceURL,
-		Screenshot: screenshot,
-		Time:       time.Now().Format("15:04"),
+		Duration:   input.Duration,
+		Module:     normalizeModule(input.Module),
+		Reflection: input.Reflection,
+		Score:      input.Score,
+		Homework:   input.Homework,
+		Learnings:  input.Learnings,
+		Content:    input.Content,
+		SourceURL:  input.SourceURL,
+		Screenshot: input.Screenshot,
+		ExamType:   input.ExamType,
+		SubTask:    input.SubTask,
+		StartedAt:  input.StartedAt,
+		EndedAt:    input.EndedAt,
+		Scores:     input.Scores,
+	}
+	log.fillOverallScore()
+	log.stampTimes(time.Now(), state.UserProfile.location())
+	err := a.state.Do(func(store Store) error {
+		if err := a.blobs.externalizeLog(&log); err != nil {
+			return err
+		}
+		return store.AddLog(log)
 	})
-	a.SaveState(state)
-}
-
-func (a *App) UpdateLastLogSession(reflection string, score float64, homework string, learnings string) {
-	state, _ := a.LoadState()
-	if len(state.DailyLogs) > 0 {
-		idx := len(state.DailyLogs) - 1
-		state.DailyLogs[idx].Reflection = reflection
-		state.DailyLogs[idx].Score = score
-		state.DailyLogs[idx].Homework = homework
-		state.DailyLogs[idx].Learnings = learnings
-		a.SaveState(state)
+	if err != nil {
+		return "", err
 	}
+	return log.ID, nil
 }
 
 func (a *App) StartScheduler() {
 	ticker := time.NewTicker(30 * time.Second) // Check more frequently
 	go func() {
+		var reportRetry time.Time // No weekly report attempt before this, after a failure
+		var reportFailed string   // Week end whose report failure was logged
 		for range ticker.C {
-			now := time.Now()
+			// 1. Time-based reminders: User custom time
+			state, _ := a.loadState()
+			loc := state.UserProfile.location()
+			now := time.Now().In(loc)
 			hour := now.Hour()
 			minute := now.Minute()
-			// 1. Time-based reminders: User custom time
-			currentTime := fmt.Sprintf("%02d:%02d", hour, minute)
-			state, _ := a.LoadState()
-
-			isReminderTime := false
-			if state.UserProfile.ReminderEnabled {
-				for _, t := range state.UserProfile.ReminderTimes {
-					if t == currentTime {
-						isReminderTime = true
-						break
-					}
-				}
-			}
 
-			if isReminderTime {
-				today := now.Format("2006-01-02")
-				todaysDuration := 0
-				for _, log := range state.DailyLogs {
-					if log.Date == today {
-						todaysDuration += log.Duration
-					}
-				}
+			if isReminderTime(state.UserProfile, now) {
+				progress := targetProgress(state.DailyLogs, state.UserProfile, now)
 
-				// Target is at least 120 mins. If less, confront.
-				if todaysDuration < 120 {
+				// Below today's target. Confront.
+				if !progress.DailyMet() {
 					briefing := a.GetEngressBriefing()
-					a.Notify("ENGRESS: Focus Check", fmt.Sprintf("It is %02d:%02d. Your daily mission is incomplete. %s", hour, minute, briefing))
+					a.Notify("ENGRESS: Focus Check", fmt.Sprintf("It is %02d:%02d. Your daily mission is incomplete (%d/%d min). %s", hour, minute, progress.DailyLogged, progress.DailyTarget, briefing))
 					runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
 						Type:          runtime.WarningDialog,
 						Title:         "ENGRESS: Focus Check",
@@ -170,28 +193,67 @@ func (a *App) StartScheduler() {
 			}
 
 			// 2. Pause reminder: If paused, increment counter. Every 20 mins
-			if a.isPaused {
-				a.pauseCounter++
-				if a.pauseCounter >= 40 { // 20 minutes
-					a.pauseCounter = 0
-					a.Notify("ENGRESS: Discipline Warning", "You have been paused for 20 minutes. Resume your training.")
-					runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
-						Type:          runtime.WarningDialog,
-						Title:         "ENGRESS: Discipline Warning",
-						Message:       "You have been paused for 20 minutes. Stop making excuses and resume your training.",
-						Buttons:       []string{"Resume Training", "Keep Paused"},
-						DefaultButton: "Resume Training",
-					})
+			pausedTooLong := false
+			a.state.UpdateRuntime(func(r *RuntimeState) {
+				if !r.IsPaused {
+					r.PauseCounter = 0
+					return
+				}
+				r.PauseCounter++
+				if r.PauseCounter >= 40 { // 20 minutes
+					r.PauseCounter = 0
+					pausedTooLong = true
+				}
+			})
+			if pausedTooLong {
+				a.Notify("ENGRESS: Discipline Warning", "You have been paused for 20 minutes. Resume your training.")
+				runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+					Type:          runtime.WarningDialog,
+					Title:         "ENGRESS: Discipline Warning",
+					Message:       "You have been paused for 20 minutes. Stop making excuses and resume your training.",
+					Buttons:       []string{"Resume Training", "Keep Paused"},
+					DefaultButton: "Resume Training",
+				})
+			}
+
+			// 3. Weekly report, once the week is over. Failures are retried
+			// hourly and logged once per week.
+			if now.After(reportRetry) {
+				if err := a.writeWeeklyReport(now); err != nil {
+					reportRetry = now.Add(weeklyReportRetry)
+					if week := dueWeekEnd(now); week != reportFailed {
+						reportFailed = week
+						a.logError("Weekly report for the week ending %s failed: %v", week, err)
+					}
 				}
-			} else {
-				a.pauseCounter = 0
 			}
 		}
 	}()
 }
 
+// isReminderTime reports whether now matches one of the profile's reminder times.
+func isReminderTime(profile UserProfile, now time.Time) bool {
+	if !profile.ReminderEnabled {
+		return false
+	}
+	currentTime := fmt.Sprintf("%02d:%02d", now.Hour(), now.Minute())
+	for _, t := range profile.ReminderTimes {
+		if t == currentTime {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *App) SetPauseState(paused bool) {
-	a.isPaused = paused
+	a.state.UpdateRuntime(func(r *RuntimeState) {
+		r.IsPaused = paused
+	})
+	a.onPauseChanged(paused)
+}
+
+// onPauseChanged tells the frontend and the HUD about a new pause state.
+func (a *App) onPauseChanged(paused bool) {
 	runtime.EventsEmit(a.ctx, "pause-state-changed", paused)
 	if paused {
 		runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
@@ -203,7 +265,7 @@ func (a *App) SetPauseState(paused bool) {
 		})
 	}
 	// Update HUD state properly instead of forcing HIDDEN
-	a.UpdateTrayTime(a.currentTimeStr)
+	a.UpdateTrayTime(a.state.Runtime().CurrentTimeStr)
 }
 
 func (a *App) startHUDCommandListener() {
@@ -218,7 +280,10 @@ func (a *App) startHUDCommandListener() {
 
 				switch cmd {
 				case "TOGGLE_PAUSE":
-					a.SetPauseState(!a.isPaused)
+					r := a.state.UpdateRuntime(func(r *RuntimeState) {
+						r.IsPaused = !r.IsPaused
+					})
+					a.onPauseChanged(r.IsPaused)
 					runtime.WindowShow(a.ctx)
 				case "STOP":
 					runtime.EventsEmit(a.ctx, "hud-stop", true)
@@ -226,8 +291,7 @@ func (a *App) startHUDCommandListener() {
 				case "OPEN":
 					runtime.WindowShow(a.ctx)
 				case "HIDE_SCRATCHPAD":
-					a.isHUDScratchpadVisible = false
-					a.UpdateTrayTime(a.currentTimeStr)
+					a.SetHUDScratchpadVisible(false)
 				}
 			}
 		}
@@ -235,104 +299,29 @@ func (a *App) startHUDCommandListener() {
 }
 
 func (a *App) SetSessionCategory(category string) {
-	a.currentCategory = category
-	if a.currentTimeStr == "HIDDEN" || a.currentTimeStr == "" {
+	r := a.state.UpdateRuntime(func(r *RuntimeState) {
+		r.CurrentCategory = category
+	})
+	if r.CurrentTimeStr == "HIDDEN" || r.CurrentTimeStr == "" {
 		a.UpdateTrayTime("---") // Placeholder to avoid 'HIDDEN' word if timer hasn't started
 	} else {
-		a.UpdateTrayTime(a.currentTimeStr)
+		a.UpdateTrayTime(r.CurrentTimeStr)
 	}
 }
 
 func (a *App) GetConsistencyPhase() string {
-	state, _ := a.LoadState()
-	return a.analyzeConsistency(state.DailyLogs)
-}
-
-func (a *App) GetEngressBriefing() string {
-	state, _ := a.LoadState()
-	logs := state.DailyLogs
-
-	// 1. Analyze Core Metrics
-	consistencyPhase := a.analyzeConsistency(logs)
-	weakest := a.analyzeWeakness(logs)
-	isDrifting := a.detectDrift(logs)
-	isComfortZone := a.checkComfortZone(logs)
-
-	name := state.UserProfile.Name
-	if name == "" {
-		name = "Candidate"
-	}
-
-	// 2. Identity-Based Messaging Engine
-	switch consistencyPhase {
-	case "Neglect":
-		return fmt.Sprintf("%s, this is not preparation. This is self-sabotage. Your discipline broke. Don't pretend it didn't.", name)
-	case "Avoiding":
-		return fmt.Sprintf("%s, you're practicing what's easy. Not what you need. Stop avoiding %s.", name, weakest)
-	case "Slipping":
-		if isDrifting {
-			return fmt.Sprintf("%s, you're drifting. Your sessions are getting shorter and your focus is fading. This is how discipline dies.", name)
-		}
-		return fmt.Sprintf("%s, your standards are dropping. Reset your standard now.", name)
-	case "Stable":
-		if isComfortZone {
-			return fmt.Sprintf("%s, stable execution, but you're hiding in your comfort zone. Confront %s today.", name, weakest)
-		}
-		return fmt.Sprintf("%s, keep the standard. Your discipline is being tested every day. Don't let it break.", name)
-	}
-
-	return fmt.Sprintf("%s, your discipline is being tested. Stand your ground.", name)
+	state, _ := a.loadState()
+	return a.analyzeConsistency(state.DailyLogs, state.Vocabulary, state.UserProfile.location())
 }
 
-func (a *App) analyzeConsistency(logs []DailyLog) string {
-	if len(logs) == 0 {
-		return "Neglect"
-	}
-
-	today := time.Now().Format("2006-01-02")
-	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
-
-	hasToday := false
-	hasYesterday := false
-	for _, log := range logs {
-		if log.Date == today {
-			hasToday = true
-		}
-		if log.Date == yesterday {
-			hasYesterday = true
-		}
-	}
-
-	if !hasToday && !hasYesterday {
-		return "Neglect"
-	}
-
-	// Check for "Avoiding" - if one module is missing for too long
-	counts := make(map[string]int)
-	for _, log := range logs {
-		counts[log.Module]++
-	}
-	for _, m := range []string{"Writing", "Speaking", "Reading", "Listening"} {
-		if counts[m] == 0 && len(logs) > 3 {
-			return "Avoiding"
-		}
-	}
-
-	if !hasToday {
-		return "Slipping"
-	}
-
-	return "Stable"
+// analyzeConsistency returns the consistency phase; see consistencyReport.
+func (a *App) analyzeConsistency(logs []DailyLog, vocab []VocabItem, loc *time.Location) string {
+	return consistencyReport(logs, reviewCounts(vocab, loc), loc, time.Now()).Phase
 }
 
-func (a *App) detectDrift(logs []DailyLog) bool {
-	if len(logs) < 4 {
-		return false
-	}
-	// Simplified: Check if average duration of last 2 sessions is less than previous 2
-	last2 := (logs[len(logs)-1].Duration + logs[len(logs)-2].Duration) / 2
-	prev2 := (logs[len(logs)-3].Duration + logs[len(logs)-4].Duration) / 2
-	return last2 < prev2
+// detectDrift reports whether any skill's session length or score is trending down; see driftReport.
+func (a *App) detectDrift(logs []DailyLog, profile UserProfile) bool {
+	return driftReport(logs, profile, time.Now()).Drifting
 }
 
 func (a *App) checkComfortZone(logs []DailyLog) bool {
@@ -352,85 +341,31 @@ func (a *App) checkComfortZone(logs []DailyLog) bool {
 	return false
 }
 
-func (a *App) analyzeWeakness(logs []DailyLog) string {
-	counts := map[string]int{
-		"Reading":   0,
-		"Writing":   0,
-		"Listening": 0,
-		"Speaking":  0,
-	}
-
-	for _, log := range logs {
-		counts[log.Module]++
-	}
-
-	minVal := 1000000
-	weakest := "Writing"
-	for _, m := range []string{"Writing", "Speaking", "Reading", "Listening"} {
-		if counts[m] < minVal {
-			minVal = counts[m]
-			weakest = m
-		}
-	}
-	return weakest
-}
-
-func (a *App) AddVocabulary(word string, def string, sentences string) {
-	state, _ := a.LoadState()
-	item := VocabItem{
-		ID:        fmt.Sprintf("%d", time.Now().UnixNano()),
-		Word:      word,
-		Def:       def,
-		Sentences: sentences,
-		DateAdded: time.Now().Format("2006-01-02"),
-		Time:      time.Now().Format("15:04"),
-	}
-	state.Vocabulary = append(state.Vocabulary, item)
-	a.SaveState(state)
-}
-
-func (a *App) DeleteVocabulary(id string) {
-	state, err := a.LoadState()
-	if err != nil || state == nil {
-		return
-	}
-	var newList []VocabItem
-	deleted := false
-	for _, item := range state.Vocabulary {
-		if item.ID != id {
-			newList = append(newList, item)
-		} else {
-			deleted = true
-		}
-	}
-	if deleted {
-		state.Vocabulary = newList
-		a.SaveState(state)
-		runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
-			Type:    runtime.InfoDialog,
-			Title:   "Deleted",
-			Message: "Vocabulary entry removed successfully.",
-		})
+// analyzeWeakness returns the weakest skill, capitalized, and the main
+// signal it ranks weakest on, with an empty Key if there is none.
+func (a *App) analyzeWeakness(logs []DailyLog, profile UserProfile) (string, WeaknessSignal) {
+	weakest := rankWeaknesses(logs, profile, time.Now())[0]
+	var signal WeaknessSignal
+	if len(weakest.Signals) > 0 {
+		signal = weakest.Signals[0]
 	}
+	return title(weakest.Module), signal
 }
 
 func (a *App) DeleteLog(id string) {
-	state, err := a.LoadState()
-	if err != nil || state == nil {
-		return
-	}
-	var newList []DailyLog
 	deleted := false
-	for _, log := range state.DailyLogs {
-		if log.ID != id {
-			newList = append(newList, log)
-		} else {
-			deleted = true
+	err := a.state.Do(func(store Store) error {
+		var err error
+		if deleted, err = store.DeleteLog(id); err != nil || !deleted {
+			return err
 		}
-	}
-	if deleted {
-		state.DailyLogs = newList
-		a.SaveState(state)
+		// The log is gone either way; unswept blobs go with the next delete
+		if err := a.collectGarbage(store); err != nil {
+			a.logError("Removing unused attachments failed: %v", err)
+		}
+		return nil
+	})
+	if err == nil && deleted {
 		runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
 			Type:    runtime.InfoDialog,
 			Title:   "Deleted",
@@ -440,42 +375,68 @@ func (a *App) DeleteLog(id string) {
 }
 
 func (a *App) GetAppState() AppState {
-	state, err := a.LoadState()
+	state, err := a.loadState()
 	if err != nil || state == nil {
 		return AppState{}
 	}
 	return *state
 }
 
+// collectGarbage removes attachments neither a remaining log nor a backup
+// refers to. It must run inside StateService.Do so a log can't be saved
+// while its blobs are swept.
+func (a *App) collectGarbage(store Store) error {
+	state, err := store.LoadState()
+	if err != nil {
+		return err
+	}
+	backups, err := store.BackupAttachments()
+	if err != nil {
+		return err
+	}
+	_, err = a.blobs.GC(state.DailyLogs, backups)
+	return err
+}
+
+// GetRuntimeState returns the session flags; later changes arrive as
+// "runtime-state-changed" events.
+func (a *App) GetRuntimeState() RuntimeState {
+	return a.state.Runtime()
+}
+
 func (a *App) UpdateTestDate(date string) {
-	state, _ := a.LoadState()
-	state.UserProfile.TestDate = date
-	a.SaveState(state)
+	a.state.UpdateProfile(func(p *UserProfile) error {
+		p.TestDate = date
+		return nil
+	})
 }
 
 func (a *App) UpdateProfileName(name string) {
-	state, _ := a.LoadState()
-	state.UserProfile.Name = name
-	a.SaveState(state)
+	a.state.UpdateProfile(func(p *UserProfile) error {
+		p.Name = name
+		return nil
+	})
 }
 
 func (a *App) UpdateReminders(enabled bool, reminderTimes []string) {
-	state, _ := a.LoadState()
-	state.UserProfile.ReminderEnabled = enabled
-	state.UserProfile.ReminderTimes = reminderTimes
-	a.SaveState(state)
+	a.state.UpdateProfile(func(p *UserProfile) error {
+		p.ReminderEnabled = enabled
+		p.ReminderTimes = reminderTimes
+		return nil
+	})
 }
 
 func (a *App) CompleteSetup(name string, date string) {
-	state, _ := a.LoadState()
-	state.UserProfile.Name = name
-	state.UserProfile.TestDate = date
-	state.UserProfile.IsSetupComplete = true
-	a.SaveState(state)
+	a.state.UpdateProfile(func(p *UserProfile) error {
+		p.Name = name
+		p.TestDate = date
+		p.IsSetupComplete = true
+		return nil
+	})
 }
 
 func (a *App) ExportData() {
-	state, _ := a.LoadState()
+	state, _ := a.loadState()
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
@@ -498,7 +459,10 @@ func (a *App) ExportData() {
 		return
 	}
 
-	err = os.WriteFile(path, data, 0644)
+	err = writeFileAtomic(path, 0644, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
 	if err != nil {
 		runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
 			Type:    runtime.ErrorDialog,
@@ -523,7 +487,9 @@ func (a *App) Notify(title string, message string) {
 func (a *App) UpdateTrayTime(timeStr string) {
 	// 1. Update Window Title (Fallback/Internal)
 	timeStr = strings.TrimSpace(timeStr)
-	a.currentTimeStr = timeStr
+	r := a.state.UpdateRuntime(func(r *RuntimeState) {
+		r.CurrentTimeStr = timeStr
+	})
 
 	displayTime := timeStr
 	if displayTime == "" || displayTime == "---" {
@@ -539,26 +505,26 @@ func (a *App) UpdateTrayTime(timeStr string) {
 	// 2. Update macOS HUD Helper
 	// Content: Time|Category|ScratchpadVisible
 	scratchVisible := "0"
-	if a.isHUDScratchpadVisible {
+	if r.HUDScratchpadVisible {
 		scratchVisible = "1"
 	}
 
 	upperTime := strings.ToUpper(timeStr)
-	upperCat := strings.ToUpper(a.currentCategory)
+	upperCat := strings.ToUpper(r.CurrentCategory)
 
 	// We only hide if explicitly requested, if paused, or if we have no active module and scratchpad is off
-	if a.isPaused || upperTime == "HIDDEN" || upperTime == "HIDE" || upperCat == "HIDDEN" {
+	if r.IsPaused || upperTime == "HIDDEN" || upperTime == "HIDE" || upperCat == "HIDDEN" {
 		os.WriteFile("/tmp/sentinel_timer.txt", []byte("HIDDEN"), 0644)
 		return
 	}
 
 	// If no category is set and no scratchpad is needed, we hide it.
-	if (a.currentCategory == "" || upperCat == "---") && !a.isHUDScratchpadVisible {
+	if (r.CurrentCategory == "" || upperCat == "---") && !r.HUDScratchpadVisible {
 		os.WriteFile("/tmp/sentinel_timer.txt", []byte("HIDDEN"), 0644)
 		return
 	}
 
-	displayCat := a.currentCategory
+	displayCat := r.CurrentCategory
 	if displayCat == "" || upperCat == "---" {
 		displayCat = "Engress"
 	}
@@ -572,21 +538,27 @@ func (a *App) UpdateNotes(notes string) {
 }
 
 func (a *App) SetHUDScratchpadVisible(visible bool) {
-	a.isHUDScratchpadVisible = visible
-	a.UpdateTrayTime(a.currentTimeStr)
+	r := a.state.UpdateRuntime(func(r *RuntimeState) {
+		r.HUDScratchpadVisible = visible
+	})
+	a.UpdateTrayTime(r.CurrentTimeStr)
 }
 
 func (a *App) startHUDNotesWatcher() {
 	ticker := time.NewTicker(500 * time.Millisecond)
 	for range ticker.C {
-		if !a.isHUDScratchpadVisible {
+		if !a.state.Runtime().HUDScratchpadVisible {
 			continue
 		}
 		data, err := os.ReadFile("/tmp/engress_notes_hud.txt")
 		if err == nil {
 			content := string(data)
-			if content != a.lastHUDNotes {
-				a.lastHUDNotes = content
+			changed := false
+			a.state.UpdateRuntime(func(r *RuntimeState) {
+				changed = content != r.LastHUDNotes
+				r.LastHUDNotes = content
+			})
+			if changed {
 				runtime.EventsEmit(a.ctx, "hud-notes-update", content)
 			}
 		}
@@ -733,17 +705,33 @@ func (a *App) shutdown(ctx context.Context) {
 
 // ResetAppData wipes the user's local data
 func (a *App) ResetAppData() string {
-	path := a.getStoragePath()
-	err := os.Remove(path)
-	if err != nil && !os.IsNotExist(err) {
+	// Keep a way back in case the reset was a mistake
+	state, _ := a.loadState()
+	if _, err := a.state.Backup(state.UserProfile.BackupsToKeep); err != nil {
+		return "Failed to back up data before reset: " + err.Error()
+	}
+	// Attachments are left on disk so the backup above stays complete
+	if err := a.state.Reset(); err != nil {
 		return "Failed to delete data: " + err.Error()
 	}
 	return "Success"
 }
 
+// UpdateBackupsToKeep sets how many rolling backups are kept in the data directory
+func (a *App) UpdateBackupsToKeep(count int) {
+	if count < 1 {
+		count = 1
+	}
+	a.state.UpdateProfile(func(p *UserProfile) error {
+		p.BackupsToKeep = count
+		return nil
+	})
+}
+
 // CompleteTutorial marks the tutorial as seen
 func (a *App) CompleteTutorial() {
-	state, _ := a.LoadState()
-	state.UserProfile.TutorialSeen = true
-	a.SaveState(state)
+	a.state.UpdateProfile(func(p *UserProfile) error {
+		p.TutorialSeen = true
+		return nil
+	})
 }
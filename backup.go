@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// defaultBackupsToKeep is used until the user picks their own number.
+const defaultBackupsToKeep = 5
+
+const backupTimeLayout = "20060102-150405"
+
+// writeFileAtomic writes to a temp file in the same directory and renames it
+// over path, so readers only ever see the old or the complete new file.
+func writeFileAtomic(path string, perm os.FileMode, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func backupDir(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), "backups")
+}
+
+// Backup writes a consistent snapshot of the database into the backups
+// directory next to it and deletes all but the newest keep snapshots.
+func (s *BoltStore) Backup(keep int) (string, error) {
+	dir := backupDir(s.db.Path())
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "engress-"+time.Now().Format(backupTimeLayout)+".db")
+	err := writeFileAtomic(path, 0600, func(w io.Writer) error {
+		return s.db.View(func(tx *bolt.Tx) error {
+			_, err := tx.WriteTo(w)
+			return err
+		})
+	})
+	if err != nil {
+		return "", fmt.Errorf("back up database: %w", err)
+	}
+	return path, pruneBackups(dir, keep)
+}
+
+// BackupAttachments lists the blob IDs referenced by the logs in the kept
+// backups, so restoring one never finds its attachments swept away.
+func (s *BoltStore) BackupAttachments() ([]string, error) {
+	var refs []string
+	for _, path := range listBackups(backupDir(s.db.Path())) {
+		db, err := bolt.Open(path, 0600, &bolt.Options{ReadOnly: true, Timeout: 2 * time.Second})
+		if err != nil {
+			return nil, fmt.Errorf("read backup %s: %w", filepath.Base(path), err)
+		}
+		err = db.View(func(tx *bolt.Tx) error {
+			if tx.Bucket(logsTable.data) == nil {
+				return nil
+			}
+			return logsTable.each(tx, func(raw []byte) error {
+				var log DailyLog
+				if err := json.Unmarshal(raw, &log); err != nil {
+					return err
+				}
+				// Backups taken before attachments were tracked only have the URLs
+				refs = append(append(refs, log.Attachments...), blobRefs(log.Screenshot, log.Content)...)
+				return nil
+			})
+		})
+		db.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read backup %s: %w", filepath.Base(path), err)
+		}
+	}
+	return refs, nil
+}
+
+// listBackups returns the backup files in dir, newest first.
+func listBackups(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), "engress-") && strings.HasSuffix(e.Name(), ".db") {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	// The timestamp format sorts lexically
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	return paths
+}
+
+func pruneBackups(dir string, keep int) error {
+	if keep <= 0 {
+		keep = defaultBackupsToKeep
+	}
+	backups := listBackups(dir)
+	for len(backups) > keep {
+		if err := os.Remove(backups[len(backups)-1]); err != nil {
+			return err
+		}
+		backups = backups[:len(backups)-1]
+	}
+	return nil
+}
+
+// backupTime parses the timestamp out of a backup file name.
+func backupTime(path string) time.Time {
+	stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "engress-"), ".db")
+	t, _ := time.ParseInLocation(backupTimeLayout, stamp, time.Local)
+	return t
+}
+
+// isCorruption reports whether err from OpenBoltStore means the file itself
+// is damaged: bbolt rejects its pages, or a record in it is not valid JSON.
+// Anything else (locked by another instance, too new for this build, a disk
+// or permission error) leaves the file alone.
+func isCorruption(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.Is(err, bolt.ErrInvalid) || errors.Is(err, bolt.ErrChecksum) || errors.Is(err, bolt.ErrVersionMismatch) ||
+		// bbolt has no sentinel for a file truncated below two pages
+		strings.Contains(err.Error(), "file size too small") ||
+		errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
+// restoreLatestBackup moves the damaged database aside and puts the newest
+// backup that opens cleanly in its place. The returned notice explains to
+// the user what happened.
+func restoreLatestBackup(dbPath, legacyJSON string, cause error) (*BoltStore, string, error) {
+	backups := listBackups(backupDir(dbPath))
+	if len(backups) == 0 {
+		return nil, "", cause
+	}
+
+	damaged := dbPath + ".damaged-" + time.Now().Format(backupTimeLayout)
+	if err := os.Rename(dbPath, damaged); err != nil {
+		return nil, "", fmt.Errorf("%w (could not move damaged file aside: %v)", cause, err)
+	}
+
+	for _, backup := range backups {
+		err := writeFileAtomic(dbPath, 0600, func(w io.Writer) error {
+			f, err := os.Open(backup)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(w, f)
+			return err
+		})
+		if err != nil {
+			continue
+		}
+		store, err := OpenBoltStore(dbPath, legacyJSON)
+		if err != nil {
+			os.Remove(dbPath)
+			continue
+		}
+		notice := fmt.Sprintf("Your data file could not be read (%v).\n\nEngress restored the backup from %s. Anything logged after that is not included. The damaged file was kept at:\n%s",
+			cause, backupTime(backup).Format("Mon, 02 Jan 2006 15:04"), damaged)
+		return store, notice, nil
+	}
+
+	// Nothing usable: put the original back so no data is lost
+	os.Rename(damaged, dbPath)
+	return nil, "", fmt.Errorf("%w (no readable backup found)", cause)
+}
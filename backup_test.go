@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestIsCorruption(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("open database: %w", bolt.ErrInvalid), true},
+		{fmt.Errorf("open database: %w", bolt.ErrChecksum), true},
+		{fmt.Errorf("open database: file size too small 5000"), true},
+		{fmt.Errorf("read database for migration: %w", &json.SyntaxError{}), true},
+		{fmt.Errorf("open database: %w", bolt.ErrTimeout), false},
+		{fmt.Errorf("open database: %w", &fs.PathError{Op: "open", Path: "engress.db", Err: fs.ErrPermission}), false},
+		{fmt.Errorf("%w (schema 9, this build supports 3)", ErrSchemaTooNew), false},
+		{errors.New("no space left on device"), false},
+	}
+	for _, tt := range tests {
+		if got := isCorruption(tt.err); got != tt.want {
+			t.Errorf("isCorruption(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestOpenDefaultStoreRestoresBackup(t *testing.T) {
+	dir := t.TempDir()
+	store, _, err := OpenDefaultStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddLog(DailyLog{ID: "1", Module: "writing", Duration: 30}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Backup(5); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	if err := os.WriteFile(filepath.Join(dir, "engress.db"), []byte("not a database"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	store, notice, err := OpenDefaultStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if notice == "" {
+		t.Error("no notice about the restore")
+	}
+	if _, err := store.GetLog("1"); err != nil {
+		t.Errorf("log from the backup: %v", err)
+	}
+}
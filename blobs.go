@@ -0,0 +1,209 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// blobURLPrefix is where the asset server exposes blobs to the frontend.
+const blobURLPrefix = "/blobs/"
+
+var (
+	// dataURLPattern matches inline base64 data URLs, including ones with
+	// parameters such as "audio/webm;codecs=opus".
+	dataURLPattern = regexp.MustCompile(`data:([a-z]+/[a-z0-9.+-]+)((?:;[a-z0-9.+-]+=[a-z0-9.+-]+)*);base64,([A-Za-z0-9+/]+=*)`)
+	blobRefPattern = regexp.MustCompile(regexp.QuoteMeta(blobURLPrefix) + `([0-9a-f]{64}\.[a-z0-9]+)`)
+	blobIDPattern  = regexp.MustCompile(`^[0-9a-f]{64}\.[a-z0-9]+$`)
+)
+
+// blobTypes maps the MIME types the frontend produces to file extensions.
+var blobTypes = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpg",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+	"audio/webm": "webm",
+	"audio/mp4":  "m4a",
+	"audio/mpeg": "mp3",
+	"audio/ogg":  "ogg",
+	"audio/wav":  "wav",
+}
+
+// BlobStore keeps attachments (screenshots, recordings) as files named by
+// the SHA-256 of their contents, so identical uploads are stored once.
+type BlobStore struct {
+	dir string
+}
+
+func NewBlobStore(dir string) (*BlobStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create blob directory: %w", err)
+	}
+	return &BlobStore{dir: dir}, nil
+}
+
+// path shards blobs into subdirectories by the first two hex digits.
+func (b *BlobStore) path(id string) string {
+	return filepath.Join(b.dir, id[:2], id)
+}
+
+// Put stores data and returns its blob ID.
+func (b *BlobStore) Put(data []byte, mimeType string) (string, error) {
+	ext, ok := blobTypes[mimeType]
+	if !ok {
+		ext = "bin"
+	}
+	sum := sha256.Sum256(data)
+	id := hex.EncodeToString(sum[:]) + "." + ext
+
+	path := b.path(id)
+	if _, err := os.Stat(path); err == nil {
+		return id, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	err := writeFileAtomic(path, 0600, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("write blob: %w", err)
+	}
+	return id, nil
+}
+
+// Externalize replaces every inline data URL in s with a blob URL and
+// returns the rewritten string.
+func (b *BlobStore) Externalize(s string) (string, error) {
+	if !strings.Contains(s, "data:") {
+		return s, nil
+	}
+	var firstErr error
+	out := dataURLPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := dataURLPattern.FindStringSubmatch(match)
+		data, err := base64.StdEncoding.DecodeString(m[3])
+		if err != nil {
+			return match
+		}
+		id, err := b.Put(data, m[1])
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return match
+		}
+		return blobURLPrefix + id
+	})
+	return out, firstErr
+}
+
+// externalizeLog moves the log's inline attachments into the blob store and
+// records which blobs it references.
+func (b *BlobStore) externalizeLog(log *DailyLog) error {
+	var err error
+	if log.Screenshot, err = b.Externalize(log.Screenshot); err != nil {
+		return err
+	}
+	if log.Content, err = b.Externalize(log.Content); err != nil {
+		return err
+	}
+	log.Attachments = blobRefs(log.Screenshot, log.Content)
+	return nil
+}
+
+// blobRefs lists the distinct blob IDs referenced in the given strings.
+func blobRefs(fields ...string) []string {
+	seen := map[string]bool{}
+	refs := []string{}
+	for _, f := range fields {
+		for _, m := range blobRefPattern.FindAllStringSubmatch(f, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				refs = append(refs, m[1])
+			}
+		}
+	}
+	return refs
+}
+
+// GC deletes every blob not referenced by one of the logs or listed in
+// keep.
+func (b *BlobStore) GC(logs []DailyLog, keep []string) (int, error) {
+	live := map[string]bool{}
+	for _, log := range logs {
+		for _, id := range log.Attachments {
+			live[id] = true
+		}
+	}
+	for _, id := range keep {
+		live[id] = true
+	}
+	removed := 0
+	err := filepath.WalkDir(b.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if blobIDPattern.MatchString(d.Name()) && !live[d.Name()] {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// externalizeAll moves inline attachments left in older logs into the blob store.
+func (b *BlobStore) externalizeAll(store Store) error {
+	state, err := store.LoadState()
+	if err != nil {
+		return err
+	}
+	for _, log := range state.DailyLogs {
+		if log.Attachments != nil && !strings.Contains(log.Screenshot+log.Content, "data:") {
+			continue
+		}
+		if err := store.UpdateLog(log.ID, b.externalizeLog); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeHTTP serves GET /blobs/<id> for the Wails asset server.
+func (b *BlobStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, blobURLPrefix)
+	if (r.Method != http.MethodGet && r.Method != http.MethodHead) || !strings.HasPrefix(r.URL.Path, blobURLPrefix) || !blobIDPattern.MatchString(id) {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(b.path(id))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ext := id[strings.LastIndex(id, ".")+1:]
+	for mimeType, e := range blobTypes {
+		if e == ext {
+			w.Header().Set("Content-Type", mimeType)
+		}
+	}
+	w.Header().Set("Cache-Control", "max-age=31536000, immutable")
+	http.ServeContent(w, r, id, info.ModTime(), f)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// A blob whose log was deleted stays while a backup still refers to it.
+func TestGCKeepsBackedUpBlobs(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenBoltStore(filepath.Join(dir, "engress.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	blobs, err := NewBlobStore(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &App{blobs: blobs}
+
+	log := DailyLog{ID: "1", Module: "writing", Screenshot: "data:image/png;base64,iVBORw0KGgo="}
+	if err := blobs.externalizeLog(&log); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddLog(log); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Backup(5); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.DeleteLog("1"); err != nil {
+		t.Fatal(err)
+	}
+	blob := blobs.path(log.Attachments[0])
+
+	if err := a.collectGarbage(store); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(blob); err != nil {
+		t.Fatalf("blob referenced by a backup was removed: %v", err)
+	}
+
+	if err := os.RemoveAll(backupDir(filepath.Join(dir, "engress.db"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.collectGarbage(store); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(blob); !os.IsNotExist(err) {
+		t.Errorf("unreferenced blob kept: %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Briefing tones and languages that ship with the app. More can be added
+// by dropping files into the override directory.
+const (
+	ToneDrillSergeant = "drill_sergeant"
+	ToneCoach         = "coach"
+	ToneSupportive    = "supportive"
+
+	defaultBriefingLanguage = "en"
+)
+
+//go:embed briefings
+var embeddedBriefings embed.FS
+
+// BriefingData is what briefing templates can refer to.
+type BriefingData struct {
+	Name    string
+	Weakest string         // Display name of the weakest skill
+	Reason  string         // Why it ranks weakest, worded by "reason.<key>" to follow a colon
+	Signal  WeaknessSignal // The numbers behind Reason
+	Module  string         // Display name of the module behind its target
+	Logged  int            // Minutes logged today
+	Target  int            // Minutes targeted today
+	Due     int            // Vocabulary reviews due today
+}
+
+// BriefingEngine renders briefing messages from template catalogs: JSON
+// files mapping message keys to text/template strings, stored as
+// <language>/<tone>.json. Files in the override directory take precedence
+// over the embedded ones key by key, so users can reword single messages.
+type BriefingEngine struct {
+	overrides fs.FS // nil when there is no override directory
+}
+
+func NewBriefingEngine(overrideDir string) *BriefingEngine {
+	e := &BriefingEngine{}
+	if overrideDir != "" {
+		e.overrides = os.DirFS(overrideDir)
+	}
+	return e
+}
+
+// loadCatalog reads a catalog, returning nil if fsys does not have it and
+// an error if it is not a JSON object of strings.
+func loadCatalog(fsys fs.FS, name string) (map[string]string, error) {
+	if fsys == nil {
+		return nil, nil
+	}
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("briefing catalog %s: %w", name, err)
+	}
+	var catalog map[string]string
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("briefing catalog %s: %w", name, err)
+	}
+	return catalog, nil
+}
+
+// catalogs returns the catalogs to search for a message, most specific
+// first: the chosen language and tone, then the language's drill sergeant
+// wording, then the same in English. Catalogs that cannot be read are
+// skipped and reported in the error.
+func (e *BriefingEngine) catalogs(language, tone string) ([]map[string]string, error) {
+	var names []string
+	for _, lang := range []string{language, defaultBriefingLanguage} {
+		for _, t := range []string{tone, ToneDrillSergeant} {
+			names = append(names, lang+"/"+t+".json")
+		}
+	}
+	var out []map[string]string
+	var errs []error
+	for _, name := range names {
+		for _, src := range []struct {
+			fsys fs.FS
+			name string
+		}{{e.overrides, name}, {embeddedBriefings, "briefings/" + name}} {
+			c, err := loadCatalog(src.fsys, src.name)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if c != nil {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// render executes the first template for key that parses and runs, or
+// returns "" if no catalog has one.
+func render(catalogs []map[string]string, key string, data BriefingData) string {
+	for _, c := range catalogs {
+		text, ok := c[key]
+		if !ok {
+			continue
+		}
+		tmpl, err := template.New(key).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			continue
+		}
+		return buf.String()
+	}
+	return ""
+}
+
+// moduleName returns the module's display name in the catalogs' language.
+func moduleName(catalogs []map[string]string, module string) string {
+	if name := render(catalogs, "module."+strings.ToLower(module), BriefingData{}); name != "" {
+		return name
+	}
+	return title(strings.ToLower(module))
+}
+
+// BriefingStyles lists the tones and languages a briefing can use.
+type BriefingStyles struct {
+	Tones     []string `json:"tones"`
+	Languages []string `json:"languages"`
+}
+
+// styles lists every <language>/<tone>.json in the embedded and override catalogs.
+func (e *BriefingEngine) styles() BriefingStyles {
+	tones, languages := map[string]bool{}, map[string]bool{}
+	collect := func(fsys fs.FS, root string) {
+		if fsys == nil {
+			return
+		}
+		files, _ := fs.Glob(fsys, path.Join(root, "*", "*.json"))
+		for _, f := range files {
+			languages[path.Base(path.Dir(f))] = true
+			tones[strings.TrimSuffix(path.Base(f), ".json")] = true
+		}
+	}
+	collect(embeddedBriefings, "briefings")
+	collect(e.overrides, ".")
+
+	sorted := func(set map[string]bool) []string {
+		out := make([]string, 0, len(set))
+		for k := range set {
+			out = append(out, k)
+		}
+		sort.Strings(out)
+		return out
+	}
+	return BriefingStyles{Tones: sorted(tones), Languages: sorted(languages)}
+}
+
+// briefingKey picks the message for the user's consistency signals.
+func briefingKey(consistencyPhase string, isDrifting, isComfortZone bool) string {
+	switch consistencyPhase {
+	case PhaseNeglect:
+		return "neglect"
+	case PhaseAvoiding:
+		return "avoiding"
+	case PhaseSlipping:
+		if isDrifting {
+			return "slipping_drift"
+		}
+		return "slipping"
+	case PhaseStable:
+		if isComfortZone {
+			return "stable_comfort"
+		}
+		return "stable"
+	}
+	return "fallback"
+}
+
+// targetReminder names what is still owed today.
+func targetReminder(catalogs []map[string]string, p TargetProgress) string {
+	if p.DailyLogged < p.DailyTarget {
+		return render(catalogs, "target_reminder", BriefingData{Logged: p.DailyLogged, Target: p.DailyTarget})
+	}
+	for _, m := range p.Modules {
+		if m.Logged < m.Target {
+			return render(catalogs, "module_target_reminder", BriefingData{Module: moduleName(catalogs, m.Module), Logged: m.Logged, Target: m.Target})
+		}
+	}
+	return ""
+}
+
+func (a *App) GetEngressBriefing() string {
+	state, _ := a.loadState()
+	logs := state.DailyLogs
+	profile := state.UserProfile
+	catalogs, err := a.briefings.catalogs(profile.BriefingLanguage, profile.BriefingTone)
+	if err != nil {
+		a.logError("Briefing catalogs ignored: %v", err)
+	}
+
+	// 1. Analyze Core Metrics
+	consistencyPhase := a.analyzeConsistency(logs, state.Vocabulary, profile.location())
+	weakest, signal := a.analyzeWeakness(logs, profile)
+	isDrifting := a.detectDrift(logs, profile)
+	isComfortZone := a.checkComfortZone(logs)
+
+	data := BriefingData{Name: profile.Name, Weakest: moduleName(catalogs, weakest)}
+	if data.Name == "" {
+		data.Name = render(catalogs, "default_name", data)
+	}
+	if signal.Key != "" {
+		data.Signal = signal
+		data.Reason = render(catalogs, "reason."+signal.Key, data)
+	}
+
+	// 2. Identity-Based Messaging Engine
+	key := briefingKey(consistencyPhase, isDrifting, isComfortZone)
+	message := render(catalogs, key, data)
+	if data.Reason != "" && (key == "avoiding" || key == "stable_comfort") {
+		if why := render(catalogs, "weakness_reason", data); why != "" {
+			message += " " + why
+		}
+	}
+
+	// 3. Hold them to today's target
+	progress := targetProgress(logs, profile, time.Now())
+	if !progress.DailyMet() {
+		if reminder := targetReminder(catalogs, progress); reminder != "" {
+			message += " " + reminder
+		}
+	}
+
+	// 4. And to the words waiting for review
+	if stats := reviewStats(state.Vocabulary, profile.location(), time.Now()); stats.Due > 0 {
+		if reminder := render(catalogs, "reviews_due", BriefingData{Due: stats.Due}); reminder != "" {
+			message += " " + reminder
+		}
+	}
+	return message
+}
+
+// GetBriefingStyles lists the briefing tones and languages available.
+func (a *App) GetBriefingStyles() BriefingStyles {
+	return a.briefings.styles()
+}
+
+// UpdateBriefingStyle sets the tone and language of the daily briefing.
+func (a *App) UpdateBriefingStyle(tone string, language string) error {
+	styles := a.briefings.styles()
+	if !contains(styles.Tones, tone) {
+		return &ValidationError{Field: "tone", Message: fmt.Sprintf("unknown tone %q", tone)}
+	}
+	if !contains(styles.Languages, language) {
+		return &ValidationError{Field: "language", Message: fmt.Sprintf("unknown language %q", language)}
+	}
+	return a.state.UpdateProfile(func(p *UserProfile) error {
+		p.BriefingTone = tone
+		p.BriefingLanguage = language
+		return nil
+	})
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Consistency phases, from best to worst. The frontend styles each by name.
+const (
+	PhaseStable   = "Stable"
+	PhaseSlipping = "Slipping"
+	PhaseAvoiding = "Avoiding"
+	PhaseNeglect  = "Neglect"
+)
+
+const (
+	// avoidanceDays is how long a skill can go unpractised before it counts as avoided.
+	avoidanceDays = 14
+	// minLogsForAvoidance is how many sessions the last 30 days need before
+	// a missing skill is treated as avoidance rather than a new user.
+	minLogsForAvoidance = 4
+	// slippingRatio is the 7-day active ratio below which the user is
+	// slipping, once there are seven days of history to judge it by.
+	slippingRatio = 0.5
+)
+
+// ModuleRecency is how long ago a skill was last practised.
+type ModuleRecency struct {
+	Module    string `json:"module"`
+	DaysSince int    `json:"days_since"` // -1 if never practised
+}
+
+// ConsistencyReport summarizes how regularly the user studies, in the
+// profile timezone. A day is active if at least one session started on it,
+// or if at least minReviewsForActiveDay vocabulary reviews were done.
+type ConsistencyReport struct {
+	Phase         string          `json:"phase"`
+	CurrentStreak int             `json:"current_streak"` // Active days up to today, or yesterday if today is still open
+	LongestStreak int             `json:"longest_streak"`
+	LastActiveDay string          `json:"last_active_day"` // Empty if there are no sessions
+	ActiveDays7   int             `json:"active_days_7"`
+	ActiveDays30  int             `json:"active_days_30"`
+	ActiveRatio7  float64         `json:"active_ratio_7"`
+	ActiveRatio30 float64         `json:"active_ratio_30"`
+	ReviewsToday  int             `json:"reviews_today"`
+	Modules       []ModuleRecency `json:"modules"`
+}
+
+// consistencyReport builds the report from session logs and the number of
+// vocabulary reviews per day (see reviewCounts).
+func consistencyReport(logs []DailyLog, reviews map[string]int, loc *time.Location, now time.Time) ConsistencyReport {
+	now = now.In(loc)
+	today, _ := time.ParseInLocation(dayLayout, now.Format(dayLayout), loc)
+	daysAgo := func(day string) int {
+		t, err := time.ParseInLocation(dayLayout, day, loc)
+		if err != nil {
+			return -1
+		}
+		// Round, since a DST change makes some days 23 or 25 hours long
+		return int(today.Sub(t).Hours()/24 + 0.5)
+	}
+
+	active := make(map[string]bool)
+	lastModule := make(map[string]string)
+	recentLogs := 0
+	for _, log := range logs {
+		day := log.day(loc)
+		active[day] = true
+		module := strings.ToLower(log.Module)
+		if day > lastModule[module] {
+			lastModule[module] = day
+		}
+		if ago := daysAgo(day); ago >= 0 && ago < 30 {
+			recentLogs++
+		}
+	}
+
+	for day, n := range reviews {
+		if n >= minReviewsForActiveDay {
+			active[day] = true
+		}
+	}
+
+	r := ConsistencyReport{ReviewsToday: reviews[today.Format(dayLayout)], Modules: []ModuleRecency{}}
+	days := make([]string, 0, len(active))
+	for day := range active {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	// Streaks over the sorted active days
+	run := 0
+	prev := ""
+	for _, day := range days {
+		if prev != "" && daysAgo(prev)-daysAgo(day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > r.LongestStreak {
+			r.LongestStreak = run
+		}
+		prev = day
+	}
+	if len(days) > 0 {
+		r.LastActiveDay = days[len(days)-1]
+		// The streak is still alive if the last active day is today or yesterday
+		if ago := daysAgo(r.LastActiveDay); ago == 0 || ago == 1 {
+			r.CurrentStreak = run
+		}
+	}
+
+	for day := range active {
+		ago := daysAgo(day)
+		if ago >= 0 && ago < 7 {
+			r.ActiveDays7++
+		}
+		if ago >= 0 && ago < 30 {
+			r.ActiveDays30++
+		}
+	}
+	r.ActiveRatio7 = float64(r.ActiveDays7) / 7
+	r.ActiveRatio30 = float64(r.ActiveDays30) / 30
+
+	// A user who started this week can't have been active on the days before
+	newUser := len(days) == 0 || daysAgo(days[0]) < 6
+
+	avoiding := false
+	for _, skill := range skills {
+		m := ModuleRecency{Module: skill, DaysSince: -1}
+		if day, ok := lastModule[skill]; ok {
+			m.DaysSince = daysAgo(day)
+		}
+		if m.DaysSince < 0 || m.DaysSince >= avoidanceDays {
+			avoiding = true
+		}
+		r.Modules = append(r.Modules, m)
+	}
+
+	switch ago := daysAgo(r.LastActiveDay); {
+	case r.LastActiveDay == "" || ago > 1:
+		r.Phase = PhaseNeglect
+	case avoiding && recentLogs >= minLogsForAvoidance:
+		r.Phase = PhaseAvoiding
+	case ago == 1 || !newUser && r.ActiveRatio7 < slippingRatio:
+		r.Phase = PhaseSlipping
+	default:
+		r.Phase = PhaseStable
+	}
+	return r
+}
+
+// GetConsistencyReport returns streaks, active-day ratios, per-skill recency
+// and the resulting consistency phase.
+func (a *App) GetConsistencyReport() (ConsistencyReport, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return ConsistencyReport{}, err
+	}
+	loc := state.UserProfile.location()
+	return consistencyReport(state.DailyLogs, reviewCounts(state.Vocabulary, loc), loc, time.Now()), nil
+}
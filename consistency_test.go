@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsistencyPhase(t *testing.T) {
+	now := time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)
+	day := func(ago int) string { return now.AddDate(0, 0, -ago).Format(dayLayout) }
+	tests := []struct {
+		name string
+		days []int // Days ago with a session
+		want string
+	}{
+		{"new user, today only", []int{0, 0}, PhaseStable},
+		{"new user, yesterday only", []int{1}, PhaseSlipping},
+		{"new user, three days in a row", []int{0, 1, 2}, PhaseStable},
+		{"a week in, most days", []int{0, 1, 2, 4, 6}, PhaseStable},
+		{"a week in, few days", []int{0, 6}, PhaseSlipping},
+		{"two weeks in, few days", []int{0, 9, 13}, PhaseSlipping},
+		{"nothing since two days ago", []int{2, 3}, PhaseNeglect},
+		{"no sessions", nil, PhaseNeglect},
+	}
+	for _, tt := range tests {
+		var logs []DailyLog
+		for i, ago := range tt.days {
+			logs = append(logs, DailyLog{Module: skills[i%len(skills)], Date: day(ago)})
+		}
+		if got := consistencyReport(logs, nil, time.UTC, now).Phase; got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
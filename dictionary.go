@@ -0,0 +1,449 @@
+package main
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed dictionary
+var embeddedDictionary embed.FS
+
+// partsOfSpeech maps WordNet's part-of-speech codes to display names.
+var partsOfSpeech = map[string]string{
+	"n": "noun",
+	"v": "verb",
+	"a": "adjective",
+	"s": "adjective", // WordNet's satellite adjectives
+	"r": "adverb",
+}
+
+// morphyRules are WordNet's suffix detachment rules, by part of speech,
+// plus "ying" and "ier", "iest", which WordNet leaves to its exception
+// lists.
+var morphyRules = map[string][][2]string{
+	"n": {{"s", ""}, {"ses", "s"}, {"xes", "x"}, {"zes", "z"}, {"ches", "ch"}, {"shes", "sh"}, {"men", "man"}, {"ies", "y"}},
+	"v": {{"s", ""}, {"ies", "y"}, {"es", "e"}, {"es", ""}, {"ed", "e"}, {"ed", ""}, {"ing", "e"}, {"ing", ""}, {"ying", "ie"}},
+	"a": {{"er", ""}, {"est", ""}, {"er", "e"}, {"est", "e"}, {"ier", "y"}, {"iest", "y"}},
+}
+
+// Sense is one meaning of a word.
+type Sense struct {
+	PartOfSpeech string   `json:"part_of_speech"`
+	Definition   string   `json:"definition"`
+	Examples     []string `json:"examples"`
+	Synonyms     []string `json:"synonyms"`
+}
+
+// LookupResult is a dictionary entry, with Def and Sentences ready to
+// pre-fill a new VocabItem.
+type LookupResult struct {
+	Query     string  `json:"query"`
+	Lemma     string  `json:"lemma"` // The base form found, e.g. "analyse" for "analysed"
+	Found     bool    `json:"found"`
+	Senses    []Sense `json:"senses"`
+	Def       string  `json:"def"`
+	Sentences string  `json:"sentences"` // Newline separated, as in VocabItem
+}
+
+// Dictionary looks words up in WordNet-style tab-separated files under
+// dictionary/: entries (*.tsv) with the columns
+//
+//	lemma, part of speech (n, v, a, s, r), definition, examples, synonyms
+//
+// where examples are separated by " | " and synonyms by commas, and
+// exceptions/*.tsv listing irregular forms as "form, lemma". Multi-word
+// lemmas use underscores, as in WordNet. Everything is loaded on the first
+// lookup.
+//
+// The embedded files are a starter dictionary of about 400 academic
+// lemmas, not WordNet, so most everyday words are not found. The override
+// directory is read after them, in the same layout or as WordNet's own
+// database files (data.noun, data.verb, data.adj, data.adv and the *.exc
+// exception lists), so copying the dict folder of WordNet 3.0 or 3.1 there
+// gives full coverage without rebuilding.
+type Dictionary struct {
+	overrides fs.FS // nil when there is no override directory
+
+	once       sync.Once
+	entries    map[string][]Sense
+	exceptions map[string][]string // Irregular form -> lemmas
+	irregular  map[string][]string // Lemma -> irregular forms
+	loadErr    error               // Files that could not be read, in full or in part
+
+	reportOnce sync.Once
+}
+
+func NewDictionary(overrideDir string) *Dictionary {
+	d := &Dictionary{}
+	if overrideDir != "" {
+		d.overrides = os.DirFS(overrideDir)
+	}
+	return d
+}
+
+func (d *Dictionary) load() {
+	d.entries = make(map[string][]Sense)
+	d.exceptions = make(map[string][]string)
+	d.irregular = make(map[string][]string)
+	var errs []error
+	read := func(fsys fs.FS, pattern string, add func(line string)) {
+		files, _ := fs.Glob(fsys, pattern)
+		for _, name := range files {
+			if err := readDictionaryFile(fsys, name, add); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	tsvEntry := func(line string) { d.addEntry(strings.Split(line, "\t")) }
+	tsvException := func(line string) { d.addException(strings.Split(line, "\t")) }
+	read(embeddedDictionary, "dictionary/*.tsv", tsvEntry)
+	read(embeddedDictionary, "dictionary/exceptions/*.tsv", tsvException)
+	if d.overrides != nil {
+		read(d.overrides, "*.tsv", tsvEntry)
+		read(d.overrides, "exceptions/*.tsv", tsvException)
+		read(d.overrides, "data.*", d.addSynset)
+		read(d.overrides, "*.exc", func(line string) { d.addException(strings.Fields(line)) })
+	}
+	d.loadErr = errors.Join(errs...)
+}
+
+// readDictionaryFile calls add with each line of a file, skipping blank
+// lines, "#" comments and the indented license header of WordNet's files.
+func readDictionaryFile(fsys fs.FS, name string, add func(line string)) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("dictionary file %s: %w", name, err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, " ") {
+			continue
+		}
+		add(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("dictionary file %s read in part: %w", name, err)
+	}
+	return nil
+}
+
+// reportLoadError calls report with the error of loading the dictionary,
+// if there was one, the first time it is called.
+func (d *Dictionary) reportLoadError(report func(error)) {
+	d.once.Do(d.load)
+	d.reportOnce.Do(func() {
+		if d.loadErr != nil {
+			report(d.loadErr)
+		}
+	})
+}
+
+func (d *Dictionary) addEntry(cols []string) {
+	if len(cols) < 3 {
+		return
+	}
+	pos, ok := partsOfSpeech[cols[1]]
+	if !ok {
+		return
+	}
+	sense := Sense{PartOfSpeech: pos, Definition: strings.TrimSpace(cols[2]), Examples: []string{}, Synonyms: []string{}}
+	if len(cols) > 3 {
+		for _, ex := range strings.Split(cols[3], " | ") {
+			if ex = strings.TrimSpace(ex); ex != "" {
+				sense.Examples = append(sense.Examples, ex)
+			}
+		}
+	}
+	if len(cols) > 4 {
+		for _, syn := range strings.Split(cols[4], ",") {
+			if syn = strings.TrimSpace(syn); syn != "" {
+				sense.Synonyms = append(sense.Synonyms, strings.ReplaceAll(syn, "_", " "))
+			}
+		}
+	}
+	key := dictionaryKey(cols[0])
+	d.entries[key] = append(d.entries[key], sense)
+}
+
+// wordNetExample matches the quoted examples of a WordNet gloss.
+var wordNetExample = regexp.MustCompile(`"([^"]+)"`)
+
+// addSynset adds a line of a WordNet data file,
+//
+//	offset lex_filenum ss_type w_cnt word lex_id [word lex_id...] p_cnt [ptr...] | gloss
+//
+// as a sense of each of its words, the others being its synonyms.
+func (d *Dictionary) addSynset(line string) {
+	fields, gloss, _ := strings.Cut(line, " | ")
+	cols := strings.Fields(fields)
+	if len(cols) < 4 {
+		return
+	}
+	pos, ok := partsOfSpeech[cols[2]]
+	if !ok {
+		return
+	}
+	count, err := strconv.ParseInt(cols[3], 16, 0)
+	if err != nil || len(cols) < 4+2*int(count) {
+		return
+	}
+	var words []string
+	for i := 0; i < int(count); i++ {
+		word := cols[4+2*i]
+		if j := strings.IndexByte(word, '('); j > 0 { // Adjective markers: "(a)", "(p)", "(ip)"
+			word = word[:j]
+		}
+		words = append(words, word)
+	}
+
+	definition := gloss
+	var examples []string
+	if i := strings.Index(gloss, `"`); i >= 0 {
+		definition = gloss[:i]
+		for _, m := range wordNetExample.FindAllStringSubmatch(gloss[i:], -1) {
+			examples = append(examples, m[1])
+		}
+	}
+	definition = strings.TrimRight(strings.TrimSpace(definition), ";")
+
+	for _, word := range words {
+		sense := Sense{PartOfSpeech: pos, Definition: definition, Examples: append([]string{}, examples...), Synonyms: []string{}}
+		for _, syn := range words {
+			if syn != word {
+				sense.Synonyms = append(sense.Synonyms, strings.ReplaceAll(syn, "_", " "))
+			}
+		}
+		key := dictionaryKey(word)
+		d.entries[key] = append(d.entries[key], sense)
+	}
+}
+
+func (d *Dictionary) addException(cols []string) {
+	if len(cols) < 2 {
+		return
+	}
+	form := dictionaryKey(cols[0])
+	for _, lemma := range cols[1:] {
+		if lemma = dictionaryKey(lemma); lemma != "" && !contains(d.exceptions[form], lemma) {
+			d.exceptions[form] = append(d.exceptions[form], lemma)
+			d.irregular[lemma] = append(d.irregular[lemma], form)
+		}
+	}
+}
+
+func dictionaryKey(word string) string {
+	return strings.Join(strings.Fields(strings.ToLower(word)), "_")
+}
+
+// baseForms returns word and every form WordNet's rules could reduce it
+// to, whether or not the dictionary has them. Many are not words, or not
+// related to word ("let" for "letter"); use stems for matching.
+func (d *Dictionary) baseForms(word string) []string {
+	d.once.Do(d.load)
+	key := dictionaryKey(word)
+	out := []string{key}
+	add := func(form string) {
+		if !contains(out, form) {
+			out = append(out, form)
+		}
+	}
+	for _, lemma := range d.exceptions[key] {
+		add(lemma)
+	}
+	for _, pos := range []string{"n", "v", "a"} {
+		for _, rule := range morphyRules[pos] {
+			if !strings.HasSuffix(key, rule[0]) || len(key) <= len(rule[0]) {
+				continue
+			}
+			stem := key[:len(key)-len(rule[0])] + rule[1]
+			add(stem)
+			// "planning" -> "plann" -> "plan"
+			if n := len(stem); rule[1] == "" && n > 2 && stem[n-1] == stem[n-2] {
+				add(stem[:n-1])
+			}
+		}
+	}
+	return out
+}
+
+// inflections returns base and the forms it inflects to: irregular forms
+// from the exception files, plurals and third persons, past tenses and
+// participles and, for adjectives in the dictionary, comparatives and
+// superlatives. Forms are generated from base by English spelling rules,
+// so unlike baseForms they are exact: "letter" is not an inflection of
+// "let", nor "planes" of "plan".
+func (d *Dictionary) inflections(base string) []string {
+	d.once.Do(d.load)
+	base = dictionaryKey(base)
+	out := append([]string{base}, d.irregular[base]...)
+	n := len(base)
+	if n < 2 || strings.Contains(base, "_") {
+		return out
+	}
+	add := func(forms ...string) {
+		for _, f := range forms {
+			if !contains(out, f) {
+				out = append(out, f)
+			}
+		}
+	}
+	last, stem := base[n-1], base[:n-1]
+	consonantY := last == 'y' && !isVowel(base[n-2])
+
+	// Plural and third person
+	switch {
+	case consonantY:
+		add(stem + "ies")
+	case strings.HasSuffix(base, "o"):
+		add(base+"s", base+"es")
+	case strings.HasSuffix(base, "s"), strings.HasSuffix(base, "x"), strings.HasSuffix(base, "z"),
+		strings.HasSuffix(base, "ch"), strings.HasSuffix(base, "sh"):
+		add(base + "es")
+	default:
+		add(base + "s")
+	}
+
+	// Past tense and participles
+	switch {
+	case strings.HasSuffix(base, "ie"):
+		add(base+"d", base[:n-2]+"ying")
+	case strings.HasSuffix(base, "ee"), strings.HasSuffix(base, "ye"), strings.HasSuffix(base, "oe"):
+		add(base+"d", base+"ing")
+	case last == 'e':
+		add(base+"d", stem+"ing")
+	case consonantY:
+		add(stem+"ied", base+"ing")
+	case last == 'c':
+		add(base+"ked", base+"king", base+"ed", base+"ing")
+	case endsCVC(base):
+		// "plan" -> "planned"; longer words double only when the last
+		// syllable is stressed, which spelling does not show: "preferred"
+		// but "visited".
+		add(base+string(last)+"ed", base+string(last)+"ing")
+		if syllables(base) > 1 {
+			add(base+"ed", base+"ing")
+		}
+	default:
+		add(base+"ed", base+"ing")
+	}
+
+	// Comparative and superlative
+	if !d.isAdjective(base) {
+		return out
+	}
+	switch {
+	case last == 'e':
+		add(base+"r", base+"st")
+	case consonantY:
+		add(stem+"ier", stem+"iest")
+	case endsCVC(base) && syllables(base) == 1:
+		add(base+string(last)+"er", base+string(last)+"est")
+	default:
+		add(base+"er", base+"est")
+	}
+	return out
+}
+
+func (d *Dictionary) isAdjective(lemma string) bool {
+	for _, s := range d.entries[lemma] {
+		if s.PartOfSpeech == "adjective" {
+			return true
+		}
+	}
+	return false
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// endsCVC reports whether word ends in consonant, vowel, consonant, the
+// spelling whose final consonant doubles before -ed and -ing.
+func endsCVC(word string) bool {
+	n := len(word)
+	return n >= 3 && !isVowel(word[n-3]) && isVowel(word[n-2]) && !isVowel(word[n-1]) &&
+		strings.IndexByte("wxy", word[n-1]) < 0
+}
+
+// syllables counts the vowel groups of word.
+func syllables(word string) int {
+	n, inVowel := 0, false
+	for i := 0; i < len(word); i++ {
+		v := isVowel(word[i]) || (word[i] == 'y' && i > 0)
+		if v && !inVowel {
+			n++
+		}
+		inVowel = v
+	}
+	return n
+}
+
+// stems returns word and the words it is an inflection of: the base forms
+// of baseForms that inflect back to word.
+func (d *Dictionary) stems(word string) []string {
+	key := dictionaryKey(word)
+	var out []string
+	for _, form := range d.baseForms(key) {
+		if form == key || contains(d.exceptions[key], form) || contains(d.inflections(form), key) {
+			out = append(out, form)
+		}
+	}
+	return out
+}
+
+// lemmas returns the stems of word that are in the dictionary, the word
+// itself first if it is one.
+func (d *Dictionary) lemmas(word string) []string {
+	var out []string
+	for _, form := range d.stems(word) {
+		if _, ok := d.entries[form]; ok {
+			out = append(out, form)
+		}
+	}
+	return out
+}
+
+// Lookup finds word, or its base form, in the dictionary.
+func (d *Dictionary) Lookup(word string) LookupResult {
+	r := LookupResult{Query: word, Senses: []Sense{}}
+	lemmas := d.lemmas(word)
+	if len(lemmas) == 0 {
+		return r
+	}
+	r.Found = true
+	r.Lemma = strings.ReplaceAll(lemmas[0], "_", " ")
+	r.Senses = d.entries[lemmas[0]]
+
+	// Pre-fill with the first sense, and examples from every sense
+	first := r.Senses[0]
+	r.Def = "(" + first.PartOfSpeech + ") " + first.Definition
+	var examples []string
+	for _, s := range r.Senses {
+		examples = append(examples, s.Examples...)
+	}
+	r.Sentences = strings.Join(examples, "\n")
+	return r
+}
+
+// LookupWord looks a word up in the offline dictionary, for pre-filling a
+// new vocabulary entry. Inflected forms are reduced to their base form.
+func (a *App) LookupWord(word string) (LookupResult, error) {
+	if strings.TrimSpace(word) == "" {
+		return LookupResult{}, &ValidationError{Field: "word", Message: "is required"}
+	}
+	a.dictionary.reportLoadError(func(err error) {
+		a.logError("Dictionary files ignored: %v", err)
+	})
+	return a.dictionary.Lookup(word), nil
+}
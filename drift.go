@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"Engress/scoring"
+)
+
+// Drift sensitivities, from least to most eager to warn.
+const (
+	SensitivityLow    = "low"
+	SensitivityMedium = "medium"
+	SensitivityHigh   = "high"
+)
+
+const (
+	defaultDriftWindowDays = 14
+	minDriftDays           = 3 // Fewer practice days than this can't show a trend
+)
+
+// driftThresholds are the drops between the first and last practice day in
+// the window that count as drift: duration as a fraction of the mean
+// session length, score in tenths of the scale (about one IELTS band).
+var driftThresholds = map[string]struct{ duration, score float64 }{
+	SensitivityLow:    {0.4, 1},
+	SensitivityMedium: {0.25, 0.5},
+	SensitivityHigh:   {0.15, 0.25},
+}
+
+// DriftSettings configures drift detection. Zero values use the defaults.
+type DriftSettings struct {
+	WindowDays  int    `json:"window_days"` // Defaults to defaultDriftWindowDays
+	Sensitivity string `json:"sensitivity"` // "low", "medium" (default) or "high"
+}
+
+func (s DriftSettings) withDefaults() DriftSettings {
+	if s.WindowDays <= 0 {
+		s.WindowDays = defaultDriftWindowDays
+	}
+	if _, ok := driftThresholds[s.Sensitivity]; !ok {
+		s.Sensitivity = SensitivityMedium
+	}
+	return s
+}
+
+// DriftPoint is one practice day in a module's drift window: the mean
+// duration and score of its sessions. Day counts whole days from the start
+// of the window.
+type DriftPoint struct {
+	Date     string  `json:"date"`
+	Day      float64 `json:"day"`
+	Sessions int     `json:"sessions"`
+	Duration float64 `json:"duration"`
+	Score    float64 `json:"score"` // 0 if no session was scored
+	scored   int
+}
+
+// add folds a session into the day's means.
+func (p *DriftPoint) add(duration int, score float64) {
+	p.Sessions++
+	p.Duration += (float64(duration) - p.Duration) / float64(p.Sessions)
+	if score > 0 {
+		p.scored++
+		p.Score += (score - p.Score) / float64(p.scored)
+	}
+}
+
+// ModuleDrift is the fitted trend of one skill's practice days, each day
+// counting once however many sessions it had. Slopes are per day and
+// intercepts are the fitted values at the start of the window, so the UI
+// can draw each line as intercept + slope*day.
+type ModuleDrift struct {
+	Module   string       `json:"module"`
+	Sessions int          `json:"sessions"`
+	Days     int          `json:"days"` // Days with a session
+	Points   []DriftPoint `json:"points"`
+
+	DurationMean      float64 `json:"duration_mean"`
+	DurationSlope     float64 `json:"duration_slope"` // Minutes per day
+	DurationIntercept float64 `json:"duration_intercept"`
+	DurationDrift     bool    `json:"duration_drift"`
+
+	ScoredSessions int     `json:"scored_sessions"`
+	ScoredDays     int     `json:"scored_days"`
+	ScoreSlope     float64 `json:"score_slope"` // Score points per day
+	ScoreIntercept float64 `json:"score_intercept"`
+	ScoreDrift     bool    `json:"score_drift"`
+}
+
+// DriftReport is the drift of every skill over the last WindowDays days.
+type DriftReport struct {
+	Settings DriftSettings `json:"settings"`
+	From     string        `json:"from"`
+	Modules  []ModuleDrift `json:"modules"`
+	Drifting bool          `json:"drifting"` // Any skill drifting in duration or score
+}
+
+// linearFit returns the least-squares line through the points, and false
+// if there are fewer than two distinct x values.
+func linearFit(xs, ys []float64) (slope, intercept float64, ok bool) {
+	n := float64(len(xs))
+	if n < 2 {
+		return 0, 0, false
+	}
+	var sx, sy, sxx, sxy float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+		sxx += xs[i] * xs[i]
+		sxy += xs[i] * ys[i]
+	}
+	den := n*sxx - sx*sx
+	if math.Abs(den) < 1e-9 {
+		return 0, sy / n, false
+	}
+	slope = (n*sxy - sx*sy) / den
+	return slope, (sy - slope*sx) / n, true
+}
+
+// span is the distance between the first and last of ascending days.
+func span(days []float64) float64 {
+	if len(days) < 2 {
+		return 0
+	}
+	return days[len(days)-1] - days[0]
+}
+
+func driftReport(logs []DailyLog, profile UserProfile, now time.Time) DriftReport {
+	settings := profile.Drift.withDefaults()
+	thresholds := driftThresholds[settings.Sensitivity]
+	exam := scoring.MustLookup(profile.ExamType)
+	loc := profile.location()
+
+	now = now.In(loc)
+	start, _ := time.ParseInLocation(dayLayout, now.AddDate(0, 0, -settings.WindowDays+1).Format(dayLayout), loc)
+	r := DriftReport{Settings: settings, From: start.Format(dayLayout), Modules: []ModuleDrift{}}
+	sorted := sortedLogs(logs, loc)
+
+	for _, skill := range skills {
+		m := ModuleDrift{Module: skill, Points: []DriftPoint{}}
+		for _, log := range sorted {
+			if !strings.EqualFold(log.Module, skill) {
+				continue
+			}
+			started := log.sessionStart(loc)
+			if started.Before(start) || started.After(now) {
+				continue
+			}
+			date := log.day(loc)
+			if n := len(m.Points); n == 0 || m.Points[n-1].Date != date {
+				day, _ := time.ParseInLocation(dayLayout, date, loc)
+				// Rounded, as a day across a DST change is not 24 hours
+				m.Points = append(m.Points, DriftPoint{Date: date, Day: math.Round(day.Sub(start).Hours() / 24)})
+			}
+			// Scores from another exam are on a different scale
+			score := log.logScores().get(skill)
+			if scoring.MustLookup(log.ExamType).Family != exam.Family {
+				score = 0
+			}
+			m.Points[len(m.Points)-1].add(log.Duration, score)
+			m.Sessions++
+			m.DurationMean += float64(log.Duration)
+			if score > 0 {
+				m.ScoredSessions++
+			}
+		}
+		m.Days = len(m.Points)
+		if m.Sessions > 0 {
+			m.DurationMean /= float64(m.Sessions)
+		}
+
+		var days, durations, scoreDays, scores []float64
+		for _, p := range m.Points {
+			days = append(days, p.Day)
+			durations = append(durations, p.Duration)
+			if p.scored > 0 {
+				scoreDays = append(scoreDays, p.Day)
+				scores = append(scores, p.Score)
+			}
+		}
+		m.ScoredDays = len(scores)
+
+		// A drop is the fitted change between the first and last practice
+		// day, not across the whole window, so a trend is not stretched
+		// over days with no sessions.
+		if slope, intercept, ok := linearFit(days, durations); ok {
+			m.DurationSlope, m.DurationIntercept = slope, intercept
+			m.DurationDrift = m.Days >= minDriftDays && m.DurationMean > 0 &&
+				-slope*span(days)/m.DurationMean >= thresholds.duration
+		}
+		if slope, intercept, ok := linearFit(scoreDays, scores); ok {
+			m.ScoreSlope, m.ScoreIntercept = slope, intercept
+			scale := exam.ScaleFor(skill)
+			unit := (scale.Max - scale.Min) / 10
+			m.ScoreDrift = m.ScoredDays >= minDriftDays && -slope*span(scoreDays)/unit >= thresholds.score
+		}
+		if m.DurationDrift || m.ScoreDrift {
+			r.Drifting = true
+		}
+		r.Modules = append(r.Modules, m)
+	}
+	return r
+}
+
+// GetDriftReport returns each skill's duration and score trend over the
+// drift window and whether it is drifting.
+func (a *App) GetDriftReport() (DriftReport, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return DriftReport{}, err
+	}
+	return driftReport(state.DailyLogs, state.UserProfile, time.Now()), nil
+}
+
+// UpdateDriftSettings sets the drift window and sensitivity.
+func (a *App) UpdateDriftSettings(settings DriftSettings) error {
+	if settings.WindowDays < 0 || settings.WindowDays > 365 {
+		return &ValidationError{Field: "window_days", Message: "must be between 0 and 365"}
+	}
+	if _, ok := driftThresholds[settings.Sensitivity]; !ok && settings.Sensitivity != "" {
+		return &ValidationError{Field: "sensitivity", Message: fmt.Sprintf("unknown sensitivity %q", settings.Sensitivity)}
+	}
+	return a.state.UpdateProfile(func(p *UserProfile) error {
+		p.Drift = settings
+		return nil
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func driftFor(t *testing.T, logs []DailyLog, module string) ModuleDrift {
+	t.Helper()
+	now := time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)
+	r := driftReport(logs, UserProfile{Timezone: "UTC"}, now)
+	for _, m := range r.Modules {
+		if m.Module == module {
+			return m
+		}
+	}
+	t.Fatalf("no drift for %s", module)
+	return ModuleDrift{}
+}
+
+func TestDriftOneDay(t *testing.T) {
+	var logs []DailyLog
+	for i, minutes := range []int{60, 60, 59} {
+		start := time.Date(2026, 10, 17, 9+3*i, 0, 0, 0, time.UTC)
+		logs = append(logs, DailyLog{Module: "writing", Duration: minutes, StartedAt: start.Format(time.RFC3339)})
+	}
+	m := driftFor(t, logs, "writing")
+	if m.Sessions != 3 || m.Days != 1 || len(m.Points) != 1 {
+		t.Errorf("got %d sessions on %d days, want 3 on 1", m.Sessions, m.Days)
+	}
+	if m.DurationDrift {
+		t.Error("sessions on one day reported as drifting")
+	}
+}
+
+func TestDriftFallingDuration(t *testing.T) {
+	var logs []DailyLog
+	for i, minutes := range []int{60, 55, 45, 40} {
+		start := time.Date(2026, 10, 10+2*i, 9, 0, 0, 0, time.UTC)
+		logs = append(logs, DailyLog{Module: "writing", Duration: minutes, StartedAt: start.Format(time.RFC3339)})
+	}
+	if m := driftFor(t, logs, "writing"); !m.DurationDrift {
+		t.Errorf("a 33%% drop over four practice days not reported, slope %.2f", m.DurationSlope)
+	}
+}
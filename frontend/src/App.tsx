@@ -12,6 +12,7 @@ import Summary from './pages/Summary';
 import Briefing from './pages/Briefing';
 import { EventsOn } from "../wailsjs/runtime/runtime";
 import { LogSession, GetAppState, SetPauseState, SetSessionCategory } from "../wailsjs/go/main/App";
+import { main } from "../wailsjs/go/models";
 import { getLocalDateString } from './utils/dateUtils';
 import { useRef } from 'react';
 import AppIcon from './assets/images/appicon.png';
@@ -62,33 +63,35 @@ function App() {
         sessionRef.current = activeSession;
     }, [activeSession]);
 
-    const refreshAppState = () => {
-        GetAppState().then(state => {
-            if (!state.user_profile.is_setup_complete) {
-                setCurrentPage('onboarding');
-            }
-            if (state.user_profile.test_date) {
-                setTestDate(state.user_profile.test_date);
-                const date = new Date(state.user_profile.test_date);
-                const diff = date.getTime() - new Date().getTime();
-                setDaysLeft(Math.ceil(diff / (1000 * 3600 * 24)));
-            }
+    const applyAppState = (state: main.AppState) => {
+        if (!state.user_profile.is_setup_complete) {
+            setCurrentPage('onboarding');
+        }
+        if (state.user_profile.test_date) {
+            setTestDate(state.user_profile.test_date);
+            const date = new Date(state.user_profile.test_date);
+            const diff = date.getTime() - new Date().getTime();
+            setDaysLeft(Math.ceil(diff / (1000 * 3600 * 24)));
+        }
 
-            const today = getLocalDateString();
-            const total = (state.daily_logs || [])
-                .filter((log: any) => log.date === today)
-                .reduce((acc: number, log: any) => acc + (log.duration || 0), 0);
-            setTodayMinutes(total);
+        const today = getLocalDateString();
+        const total = (state.daily_logs || [])
+            .filter((log: any) => log.date === today)
+            .reduce((acc: number, log: any) => acc + (log.duration || 0), 0);
+        setTodayMinutes(total);
 
-            setIsLoading(false);
-        });
+        setIsLoading(false);
+    };
+
+    const refreshAppState = () => {
+        GetAppState().then(applyAppState);
     };
 
     useEffect(() => {
         refreshAppState();
 
-        // Background sync to keep header and analytics updated globally
-        const syncInterval = setInterval(refreshAppState, 10000);
+        // The backend pushes every change, so the header stays current without polling
+        const unlistenState = EventsOn("state-changed", applyAppState);
 
         const unlistenUrl = EventsOn("url-active", (url: string) => {
             setActiveUrl(url);
@@ -101,7 +104,7 @@ function App() {
         });
 
         return () => {
-            clearInterval(syncInterval);
+            unlistenState();
             unlistenUrl();
             unlistenStop();
         };
@@ -186,20 +189,22 @@ function App() {
             content = sessionData?.text || sessionData?.premise || sessionData?.notes || "No specific content recorded.";
         }
 
-        await LogSession(
-            category || 'General',
-            "", // reflection
-            0,  // score
-            "", // homework
+        const logId = await LogSession(main.SessionInput.createFrom({
+            module: category || 'General',
             duration,
-            "", // learnings
+            score: 0,
+            reflection: "",
+            homework: "",
+            learnings: "",
             content,
-            sessionData?.sourceUrl || sessionData?.task1Data?.sourceUrl || sessionData?.task2Data?.sourceUrl || "",
-            sessionData?.screenshot || sessionData?.task1Data?.screenshot || sessionData?.task2Data?.screenshot || ""
-        );
+            source_url: sessionData?.sourceUrl || sessionData?.task1Data?.sourceUrl || sessionData?.task2Data?.sourceUrl || "",
+            screenshot: sessionData?.screenshot || sessionData?.task1Data?.screenshot || sessionData?.task2Data?.screenshot || "",
+            started_at: sessionToSave.startTime > 0 ? new Date(sessionToSave.startTime).toISOString() : undefined,
+            ended_at: new Date().toISOString()
+        }));
 
         // Update Summary view with the final extracted content
-        setLastSessionData((prev: any) => prev ? { ...prev, data: { ...prev.data, content } } : prev);
+        setLastSessionData((prev: any) => prev ? { ...prev, logId, data: { ...prev.data, content } } : prev);
         refreshAppState();
     };
 
@@ -1,7 +1,8 @@
 import { motion, AnimatePresence } from 'framer-motion';
 import { BarChart3, TrendingUp, Calendar, ChevronRight, Target, Brain, ShieldCheck, List, ChevronLeft, Flame, ArrowRight, X } from 'lucide-react';
 import { useState, useEffect, useMemo } from 'react';
-import { GetAppState, ExportData } from "../../wailsjs/go/main/App";
+import { GetAppState, ExportData, ExportReport, GetWeaknesses, GetConsistencyReport, GetVocabularyCoverage, GetVocabularyUsage } from "../../wailsjs/go/main/App";
+import { main } from "../../wailsjs/go/models";
 import { getLocalDateString } from '../utils/dateUtils';
 import { getCategoryColorClass } from '../utils/categoryColors';
 
@@ -13,14 +14,25 @@ const Analytics = ({ onNavigate }: { onNavigate: (page: string, params?: string,
     const [currentMonth, setCurrentMonth] = useState(new Date());
     const [weeklyPulse, setWeeklyPulse] = useState<number[]>(new Array(7).fill(0));
     const [showStreakDetail, setShowStreakDetail] = useState(false);
+    const [reportPeriod, setReportPeriod] = useState<'week' | 'month'>('week');
     const [showScoreDetail, setShowScoreDetail] = useState(false);
     const [showPhaseDetail, setShowPhaseDetail] = useState(false);
     const [showTrainingDetail, setShowTrainingDetail] = useState(false);
     const [streak, setStreak] = useState(0);
-    const [consistencyPhase, setConsistencyPhase] = useState<'Stable' | 'Slipping' | 'Neglect'>('Stable');
+    const [consistencyPhase, setConsistencyPhase] = useState<string>('Stable');
     const [retentionRate, setRetentionRate] = useState(100);
+    const [weaknesses, setWeaknesses] = useState<main.Weakness[]>([]);
+    const [coverage, setCoverage] = useState<main.VocabularyCoverage | null>(null);
+    const [usage, setUsage] = useState<main.VocabularyUsage | null>(null);
 
     useEffect(() => {
+        GetWeaknesses().then(setWeaknesses).catch(() => setWeaknesses([]));
+        GetVocabularyCoverage().then(setCoverage).catch(() => { });
+        GetVocabularyUsage().then(setUsage).catch(() => { });
+        GetConsistencyReport().then(report => {
+            setStreak(report.current_streak);
+            setConsistencyPhase(report.phase);
+        }).catch(() => { });
         GetAppState().then(state => {
             if (state.user_profile.test_date) {
                 const date = new Date(state.user_profile.test_date);
@@ -31,30 +43,6 @@ const Analytics = ({ onNavigate }: { onNavigate: (page: string, params?: string,
             const logs = state.daily_logs || [];
             setSessionLogs(logs);
 
-            // Calculate Current Streak
-            let currentStreak = 0;
-            const uniqueDates = Array.from(new Set(logs.map((l: any) => l.date))).sort().reverse();
-            const todayStr = getLocalDateString();
-            const yesterdayDate = new Date();
-            yesterdayDate.setDate(yesterdayDate.getDate() - 1);
-            const yesterdayStr = getLocalDateString(yesterdayDate);
-
-            if (uniqueDates.length > 0) {
-                if (uniqueDates[0] === todayStr || uniqueDates[0] === yesterdayStr) {
-                    currentStreak = 1;
-                    let lastDate = new Date(uniqueDates[0]);
-                    for (let i = 1; i < uniqueDates.length; i++) {
-                        const prevDate = new Date(uniqueDates[i]);
-                        const gap = Math.floor((lastDate.getTime() - prevDate.getTime()) / (1000 * 3600 * 24));
-                        if (gap === 1) {
-                            currentStreak++;
-                            lastDate = prevDate;
-                        } else { break; }
-                    }
-                }
-            }
-            setStreak(currentStreak);
-
             // Calculate Weekly Pulse
             const pulse = new Array(7).fill(0);
             const todayObj = new Date();
@@ -67,12 +55,6 @@ const Analytics = ({ onNavigate }: { onNavigate: (page: string, params?: string,
             }
             setWeeklyPulse(pulse);
 
-            // Determine Consistency Phase
-            const missedDays = uniqueDates.length > 0 && uniqueDates[0] !== todayStr ? 1 : 0;
-            if (missedDays > 3) setConsistencyPhase('Neglect');
-            else if (missedDays > 0) setConsistencyPhase('Slipping');
-            else setConsistencyPhase('Stable');
-
             // Rough Retention Rate (Vocab progress estimate)
             const vocab = state.vocabulary || [];
             if (vocab.length > 0) {
@@ -303,12 +285,97 @@ const Analytics = ({ onNavigate }: { onNavigate: (page: string, params?: string,
                             ))}
                         </div>
 
+                        {weaknesses.length > 0 && (
+                            <div className="space-y-3 pt-6 border-t border-white/5">
+                                <span className="text-[10px] font-black text-zinc-500 uppercase tracking-widest">Weakness Ranking</span>
+                                {weaknesses.map((w, idx) => (
+                                    <div key={w.module} className="flex items-start justify-between gap-4">
+                                        <div className="space-y-1 min-w-0">
+                                            <span className="text-[10px] font-black uppercase tracking-widest text-zinc-100">{idx + 1}. {w.module}</span>
+                                            {w.reasons.length > 0 && (
+                                                <p className="text-[9px] font-bold text-zinc-500 italic">{w.reasons.join(' · ')}</p>
+                                            )}
+                                        </div>
+                                        <span className={`text-xs font-black italic tabular-nums ${w.score >= 0.5 ? 'text-rose-400' : 'text-zinc-400'}`}>{Math.round(w.score * 100)}</span>
+                                    </div>
+                                ))}
+                            </div>
+                        )}
+
+                        {usage && usage.saved > 0 && (
+                            <div className="space-y-3 pt-6 border-t border-white/5">
+                                <div className="flex items-center justify-between">
+                                    <span className="text-[10px] font-black text-zinc-500 uppercase tracking-widest">Forge Words In Essays</span>
+                                    <span className="text-[10px] font-black text-emerald-400 tabular-nums">{usage.used}/{usage.saved} · {Math.round(usage.percent)}%</span>
+                                </div>
+                                <div className="flex items-end gap-1 h-10">
+                                    {usage.weekly.map(w => (
+                                        <div
+                                            key={w.week}
+                                            title={`Week of ${w.week}: ${w.used} words used in ${w.essays} essays`}
+                                            className="flex-1 bg-emerald-500/40 rounded-sm"
+                                            style={{ height: `${Math.max(4, (w.used / Math.max(1, ...usage.weekly.map(x => x.used))) * 100)}%` }}
+                                        />
+                                    ))}
+                                </div>
+                                {usage.words.length > 0 && (
+                                    <p className="text-[9px] font-bold text-zinc-600 italic truncate">Most used: {usage.words.slice(0, 5).map(w => w.word).join(', ')}</p>
+                                )}
+                                {usage.candidates.length > 0 && (
+                                    <p className="text-[9px] font-bold text-zinc-600 italic truncate">From your reading: {usage.candidates.slice(0, 5).map(c => c.word).join(', ')}</p>
+                                )}
+                            </div>
+                        )}
+
+                        {coverage && (
+                            <div className="space-y-3 pt-6 border-t border-white/5">
+                                <div className="flex items-center justify-between">
+                                    <span className="text-[10px] font-black text-zinc-500 uppercase tracking-widest">Word List Coverage</span>
+                                    <span className="text-[10px] font-black text-amber-400 tabular-nums">AWL {coverage.awl_covered}/{coverage.awl_total}</span>
+                                </div>
+                                {coverage.lists.map(list => (
+                                    <div key={list.id} className="space-y-1" title={list.suggestions.map(s => s.def ? `${s.word}: ${s.def}` : s.word).join('\n')}>
+                                        <div className="flex justify-between text-[9px] font-black uppercase tracking-widest">
+                                            <span className={list.kind === 'awl' ? 'text-zinc-300' : 'text-zinc-500'}>{list.name}</span>
+                                            <span className="text-zinc-500 tabular-nums">{Math.round(list.percent)}%</span>
+                                        </div>
+                                        <div className="h-1 bg-zinc-900 rounded-full overflow-hidden">
+                                            <div className="h-full bg-amber-500/60" style={{ width: `${list.percent}%` }} />
+                                        </div>
+                                        {list.suggestions.length > 0 && list.percent < 100 && (
+                                            <p className="text-[9px] font-bold text-zinc-600 italic truncate">Next: {list.suggestions.map(s => s.word).join(', ')}</p>
+                                        )}
+                                    </div>
+                                ))}
+                            </div>
+                        )}
+
                         <button
                             onClick={() => ExportData()}
                             className="w-full mt-4 flex items-center justify-center gap-2 py-4 rounded-2xl bg-white/5 hover:bg-white/10 border border-white/10 text-[10px] font-black uppercase tracking-widest text-zinc-400 hover:text-white transition-all"
                         >
                             Export Strategy Report <ArrowRight className="w-3.5 h-3.5" />
                         </button>
+
+                        <div className="mt-3 flex items-center gap-2">
+                            <select
+                                value={reportPeriod}
+                                onChange={(e) => setReportPeriod(e.target.value as 'week' | 'month')}
+                                className="flex-1 py-3 px-3 rounded-xl bg-zinc-950 border border-white/10 text-[9px] font-black uppercase tracking-widest text-zinc-400 outline-none"
+                            >
+                                <option value="week" className="bg-zinc-950">This Week</option>
+                                <option value="month" className="bg-zinc-950">This Month</option>
+                            </select>
+                            {['md', 'html', 'pdf'].map(format => (
+                                <button
+                                    key={format}
+                                    onClick={() => ExportReport(reportPeriod, format)}
+                                    className="py-3 px-3 rounded-xl bg-white/5 hover:bg-white/10 border border-white/10 text-[9px] font-black uppercase tracking-widest text-zinc-400 hover:text-white transition-all"
+                                >
+                                    {format}
+                                </button>
+                            ))}
+                        </div>
                     </div>
                 </div>
             </div>
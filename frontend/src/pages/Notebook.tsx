@@ -1,8 +1,9 @@
 import { useState, useEffect, useRef } from 'react';
 import { motion, AnimatePresence } from 'framer-motion';
 import { Search, Calendar as CalendarIcon, Clock, ChevronRight, Book, Lightbulb, X, Image as ImageIcon, ExternalLink, ChevronLeft, PenTool, Mic, BookOpen, Headphones, Trophy, Zap, Trash2 } from 'lucide-react';
-import { GetAppState, DeleteLog, DeleteVocabulary, Notify } from "../../wailsjs/go/main/App";
-import { BrowserOpenURL } from '../../wailsjs/runtime/runtime';
+import { GetAppState, DeleteLog, DeleteVocabulary, Notify, PreviewVocabularyImport, ImportVocabulary, ExportVocabulary, SetVocabularyTopic, AnalyzeLogVocabulary, AddVocabulary, UpdateVocabulary, MergeDuplicateVocabulary } from "../../wailsjs/go/main/App";
+import { BrowserOpenURL, EventsOn } from '../../wailsjs/runtime/runtime';
+import { main } from '../../wailsjs/go/models';
 import EngressCalendar from '../components/EngressCalendar';
 import { getCategoryColorClass } from '../utils/categoryColors';
 
@@ -13,6 +14,9 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
     onRefresh?: () => void
 }) => {
     const [activeTab, setActiveTab] = useState<'vocabulary' | 'sessions'>(initialTab);
+    const [importPreview, setImportPreview] = useState<main.VocabImportPreview | null>(null);
+    const [importMapping, setImportMapping] = useState({ word: 0, def: 1, sentences: 2, topic: -1, tags: -1 });
+    const [importMessage, setImportMessage] = useState('');
     const [showStartCalendar, setShowStartCalendar] = useState(false);
     const [showEndCalendar, setShowEndCalendar] = useState(false);
     const [showDetail, setShowDetail] = useState(false);
@@ -25,6 +29,7 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
     const [dateRange, setDateRange] = useState<{ start: string; end: string }>({ start: '', end: '' });
     const [activeWritingTask, setActiveWritingTask] = useState<'task1' | 'task2'>('task1');
     const [previewImage, setPreviewImage] = useState<string | null>(null);
+    const [logVocab, setLogVocab] = useState<main.LogVocabulary | null>(null);
 
     const handleUrlClick = (url: string, e: React.MouseEvent) => {
         e.preventDefault();
@@ -67,16 +72,70 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
         return { type: 'writing_v2', task1, task2, submittedEssays: [] };
     };
 
-    const fetchData = async () => {
-        const state = await GetAppState();
+    const applyState = (state: main.AppState) => {
         setVocabList(state.vocabulary || []);
         setSessionLogs(state.daily_logs || []);
     };
 
+    const handlePreviewImport = async () => {
+        setImportMessage('');
+        try {
+            const preview = await PreviewVocabularyImport();
+            if (!preview) return;
+            setImportPreview(preview);
+            setImportMapping(preview.mapping);
+        } catch (err: any) {
+            setImportMessage(String(err));
+        }
+    };
+
+    const handleImport = async () => {
+        if (!importPreview) return;
+        try {
+            const result = await ImportVocabulary(importPreview.path, main.VocabFieldMapping.createFrom(importMapping));
+            setImportPreview(null);
+            setImportMessage(`${result.imported} imported` + (result.duplicates.length ? `, ${result.duplicates.length} duplicates skipped` : '') + (result.skipped ? `, ${result.skipped} empty rows skipped` : ''));
+            fetchData();
+        } catch (err: any) {
+            setImportMessage(String(err));
+        }
+    };
+
+    useEffect(() => {
+        setLogVocab(null);
+        if (!selectedItem || selectedItem.type === 'vocab' || !selectedItem.id) return;
+        AnalyzeLogVocabulary(selectedItem.id).then(setLogVocab).catch(() => { });
+    }, [selectedItem?.id]);
+
+    const handleForgeCandidate = async (c: main.VocabCandidate) => {
+        await AddVocabulary(c.word, c.def, c.example, '', []);
+        if (logVocab) setLogVocab(main.LogVocabulary.createFrom({ ...logVocab, candidates: logVocab.candidates.filter(x => x.word !== c.word) }));
+    };
+
+    const handleMergeDuplicates = async () => {
+        try {
+            const result = await MergeDuplicateVocabulary();
+            setImportMessage(result.deleted ? `${result.deleted} duplicates merged into ${result.merged} entries` : 'No duplicates found');
+        } catch (err: any) {
+            setImportMessage(String(err));
+        }
+    };
+
+    const handleEditVocab = async (patch: Partial<main.VocabPatch>) => {
+        try {
+            setSelectedItem({ ...selectedItem, ...(await UpdateVocabulary(selectedItem.id, main.VocabPatch.createFrom(patch))) });
+        } catch (err: any) {
+            Notify("Edit Failed", String(err));
+        }
+    };
+
+    const fetchData = async () => {
+        applyState(await GetAppState());
+    };
+
     useEffect(() => {
         fetchData();
-        const interval = setInterval(fetchData, 5000);
-        return () => clearInterval(interval);
+        return EventsOn("state-changed", applyState);
     }, []);
 
     useEffect(() => {
@@ -172,7 +231,9 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
 
     const filteredVocab = vocabList.filter(item => {
         const matchesSearch = item.word.toLowerCase().includes(searchQuery.toLowerCase()) ||
-            item.def.toLowerCase().includes(searchQuery.toLowerCase());
+            item.def.toLowerCase().includes(searchQuery.toLowerCase()) ||
+            (item.topic || '') === searchQuery.toLowerCase() ||
+            (item.tags || []).includes(searchQuery.toLowerCase());
 
         let matchesDate = true;
         if (dateRange.start && dateRange.end) {
@@ -241,6 +302,30 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
                     </button>
                 </div>
 
+                {activeTab === 'vocabulary' && (
+                    <div className="flex flex-col gap-2">
+                        <div className="flex gap-2">
+                            {[
+                                { label: 'Import', action: handlePreviewImport },
+                                { label: 'Export CSV', action: () => ExportVocabulary('csv') },
+                                { label: 'Export Anki', action: () => ExportVocabulary('anki') },
+                                { label: 'Merge Duplicates', action: handleMergeDuplicates },
+                            ].map(b => (
+                                <button
+                                    key={b.label}
+                                    onClick={b.action}
+                                    className="flex-1 py-2 rounded-xl bg-zinc-900/50 border border-white/5 text-[9px] font-black uppercase tracking-widest text-zinc-500 hover:text-white hover:border-emerald-500/30 transition-all"
+                                >
+                                    {b.label}
+                                </button>
+                            ))}
+                        </div>
+                        {importMessage && (
+                            <span className="text-[9px] font-bold text-zinc-500 uppercase tracking-widest pl-2">{importMessage}</span>
+                        )}
+                    </div>
+                )}
+
                 {/* Date Range Filter */}
                 <div className="flex flex-col gap-2">
                     <span className="text-[9px] font-black text-zinc-600 uppercase tracking-widest pl-2">Filter by Period</span>
@@ -465,6 +550,18 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
                                         <span className="text-zinc-500 text-xs font-mono">{selectedItem.date_added} @ {selectedItem.time || '--:--'}</span>
                                     </div>
                                     <h1 className="text-4xl sm:text-5xl xl:text-7xl font-black text-white italic tracking-tighter uppercase break-words leading-none">{selectedItem.word}</h1>
+                                    <div className="flex items-center gap-2 flex-wrap">
+                                        {selectedItem.topic && (
+                                            <button onClick={() => setSearchQuery(selectedItem.topic)} className="bg-amber-500/10 border border-amber-500/20 text-amber-400 px-3 py-1 rounded-full text-[10px] font-black uppercase tracking-widest">{selectedItem.topic}</button>
+                                        )}
+                                        <input
+                                            key={selectedItem.id}
+                                            defaultValue={(selectedItem.tags || []).join(', ')}
+                                            onBlur={(e) => SetVocabularyTopic(selectedItem.id, selectedItem.topic || '', [e.target.value])}
+                                            placeholder="Add tags..."
+                                            className="flex-1 min-w-0 bg-transparent text-xs font-bold text-zinc-500 outline-none placeholder:text-zinc-700"
+                                        />
+                                    </div>
                                 </div>
 
                                 <div className="space-y-6">
@@ -472,7 +569,14 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
                                         <Book className="w-4 h-4" /> Formal Definition
                                     </h3>
                                     <div className="bg-emerald-500/5 rounded-2xl p-8 border border-emerald-500/10">
-                                        <p className="text-2xl font-bold text-emerald-100 leading-relaxed italic">"{selectedItem.def}"</p>
+                                        <textarea
+                                            key={selectedItem.id}
+                                            defaultValue={selectedItem.def}
+                                            onBlur={(e) => e.target.value !== selectedItem.def && handleEditVocab({ def: e.target.value })}
+                                            placeholder="Add a definition..."
+                                            rows={2}
+                                            className="w-full bg-transparent text-2xl font-bold text-emerald-100 leading-relaxed italic outline-none resize-none placeholder:text-emerald-900"
+                                        />
                                     </div>
                                 </div>
 
@@ -481,9 +585,14 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
                                         <Lightbulb className="w-4 h-4" /> Contextual Usage
                                     </h3>
                                     <div className="bg-zinc-900/50 rounded-[2.5rem] p-10 border border-white/5">
-                                        <p className="text-lg text-zinc-300 leading-relaxed whitespace-pre-wrap font-serif">
-                                            {selectedItem.sentences}
-                                        </p>
+                                        <textarea
+                                            key={selectedItem.id}
+                                            defaultValue={selectedItem.sentences}
+                                            onBlur={(e) => e.target.value !== selectedItem.sentences && handleEditVocab({ sentences: e.target.value })}
+                                            placeholder="Add example sentences, one per line..."
+                                            rows={Math.max(3, (selectedItem.sentences || '').split('\n').length + 1)}
+                                            className="w-full bg-transparent text-lg text-zinc-300 leading-relaxed font-serif outline-none resize-none placeholder:text-zinc-700"
+                                        />
                                     </div>
                                 </div>
                             </>
@@ -736,6 +845,48 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
                                         )}
                                     </div>
                                 </div>
+                                {logVocab && (logVocab.used.length > 0 || logVocab.candidates.length > 0) && (
+                                    <div className="space-y-6">
+                                        <h3 className="text-sm font-bold text-amber-400 uppercase tracking-widest flex items-center gap-2">
+                                            <Book className="w-4 h-4" /> Vocabulary In This Session
+                                        </h3>
+                                        {logVocab.used.length > 0 && (
+                                            <div className="space-y-2">
+                                                <span className="text-[10px] font-black text-zinc-500 uppercase tracking-widest">Forged words used · {logVocab.words} words analysed</span>
+                                                <div className="flex flex-wrap gap-2">
+                                                    {logVocab.used.map(u => (
+                                                        <span key={u.id} className="bg-emerald-500/10 border border-emerald-500/20 text-emerald-400 px-3 py-1 rounded-full text-[10px] font-black uppercase tracking-widest">
+                                                            {u.word}{u.count > 1 && ` ×${u.count}`}
+                                                        </span>
+                                                    ))}
+                                                </div>
+                                            </div>
+                                        )}
+                                        {logVocab.candidates.length > 0 && (
+                                            <div className="space-y-2">
+                                                <span className="text-[10px] font-black text-zinc-500 uppercase tracking-widest">Worth forging</span>
+                                                {logVocab.candidates.map(c => (
+                                                    <div key={c.word} className="flex items-start justify-between gap-4 p-4 bg-zinc-900/50 rounded-2xl border border-white/5">
+                                                        <div className="space-y-1 min-w-0">
+                                                            <p className="text-sm font-black text-white">
+                                                                {c.word}
+                                                                {c.sublist > 0 && <span className="ml-2 text-[9px] font-black text-amber-500/70 uppercase tracking-widest">AWL {c.sublist}</span>}
+                                                            </p>
+                                                            {c.def && <p className="text-xs text-zinc-400">{c.def}</p>}
+                                                            <p className="text-xs text-zinc-600 italic truncate">{c.example}</p>
+                                                        </div>
+                                                        <button
+                                                            onClick={() => handleForgeCandidate(c)}
+                                                            className="px-4 py-2 bg-amber-500/10 hover:bg-amber-500 text-amber-400 hover:text-white rounded-xl text-[10px] font-black uppercase tracking-widest transition-all"
+                                                        >
+                                                            Forge
+                                                        </button>
+                                                    </div>
+                                                ))}
+                                            </div>
+                                        )}
+                                    </div>
+                                )}
                                 <div className="space-y-6 pt-12 border-t border-white/5 opacity-50 hover:opacity-100 transition-opacity">
                                     <div className="flex items-center justify-between p-8 bg-rose-500/5 rounded-[2.5rem] border border-rose-500/10">
                                         <div className="space-y-1">
@@ -813,6 +964,56 @@ const Notebook = ({ initialTab = 'sessions', initialSearch = '', initialId = nul
                     </motion.div>
                 )}
             </AnimatePresence>
+
+            {/* Vocabulary Import Mapping */}
+            <AnimatePresence>
+                {importPreview && (
+                    <motion.div
+                        initial={{ opacity: 0 }}
+                        animate={{ opacity: 1 }}
+                        exit={{ opacity: 0 }}
+                        className="fixed inset-0 z-[200] bg-black/80 backdrop-blur-sm flex items-center justify-center p-6"
+                        onClick={() => setImportPreview(null)}
+                    >
+                        <div className="w-full max-w-2xl bg-zinc-950 border border-white/10 rounded-3xl p-6 space-y-5" onClick={(e) => e.stopPropagation()}>
+                            <div>
+                                <h3 className="text-sm font-black text-white uppercase tracking-widest">Import Vocabulary</h3>
+                                <span className="text-[9px] font-bold text-zinc-600 uppercase tracking-widest">{importPreview.total} rows found</span>
+                            </div>
+                            <div className="grid grid-cols-5 gap-3">
+                                {([['word', 'Word'], ['def', 'Definition'], ['sentences', 'Sentences'], ['topic', 'Topic'], ['tags', 'Tags']] as const).map(([field, label]) => (
+                                    <label key={field} className="p-3 bg-zinc-900 border border-white/5 rounded-xl space-y-1">
+                                        <span className="text-[8px] font-black text-zinc-600 uppercase tracking-widest">{label}</span>
+                                        <select
+                                            value={importMapping[field]}
+                                            onChange={(e) => setImportMapping({ ...importMapping, [field]: Number(e.target.value) })}
+                                            className="w-full bg-transparent text-white font-bold outline-none text-[10px]"
+                                        >
+                                            {field !== 'word' && <option value={-1} className="bg-zinc-950">None</option>}
+                                            {importPreview.columns.map((c, i) => <option key={i} value={i} className="bg-zinc-950">{c}</option>)}
+                                        </select>
+                                    </label>
+                                ))}
+                            </div>
+                            <div className="max-h-48 overflow-auto rounded-xl border border-white/5">
+                                <table className="w-full text-[10px] text-zinc-400">
+                                    <tbody>
+                                        {importPreview.rows.map((row, i) => (
+                                            <tr key={i} className="border-b border-white/5">
+                                                {row.map((cell, j) => <td key={j} className="p-2 align-top whitespace-pre-line">{cell}</td>)}
+                                            </tr>
+                                        ))}
+                                    </tbody>
+                                </table>
+                            </div>
+                            <div className="flex gap-3">
+                                <button onClick={() => setImportPreview(null)} className="flex-1 py-3 rounded-xl bg-zinc-900 border border-white/5 text-[10px] font-black uppercase tracking-widest text-zinc-500 hover:text-white">Cancel</button>
+                                <button onClick={handleImport} className="flex-1 py-3 rounded-xl bg-emerald-600 text-[10px] font-black uppercase tracking-widest text-white hover:bg-emerald-500">Import</button>
+                            </div>
+                        </div>
+                    </motion.div>
+                )}
+            </AnimatePresence>
         </div>
     );
 };
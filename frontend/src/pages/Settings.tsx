@@ -1,7 +1,8 @@
 import { useState, useEffect } from 'react';
 import { motion, AnimatePresence } from 'framer-motion';
-import { Settings as SettingsIcon, Calendar, Save, Zap, Bell, Lock, Clock, Shield, User, Trash2, AlertTriangle, ChevronRight, X } from 'lucide-react';
-import { GetAppState, UpdateTestDate, UpdateReminders, UpdateProfileName, GetAppVersion, CheckUpdate, DownloadUpdate, ResetAppData, Notify } from "../../wailsjs/go/main/App";
+import { Settings as SettingsIcon, Calendar, Save, Zap, Bell, Lock, Clock, Shield, User, Trash2, AlertTriangle, ChevronRight, X, Target } from 'lucide-react';
+import { GetAppState, UpdateTestDate, UpdateReminders, UpdateProfileName, GetAppVersion, CheckUpdate, DownloadUpdate, ResetAppData, Notify, UpdateStudyTargets, GetBriefingStyles, UpdateBriefingStyle, UpdateWeeklyReport, ChooseReportFolder } from "../../wailsjs/go/main/App";
+import { main } from "../../wailsjs/go/models";
 import { WindowReload } from "../../wailsjs/runtime/runtime";
 import EngressCalendar from '../components/EngressCalendar';
 import appIcon from '../assets/images/appicon.png';
@@ -14,6 +15,14 @@ const Settings = ({ onRefresh }: { onRefresh?: () => void }) => {
     const [reminderEnabled, setReminderEnabled] = useState(true);
     const [reminderTimes, setReminderTimes] = useState<string[]>(['10:00', '22:00']);
     const [appVersion, setAppVersion] = useState('v0.0.0');
+    const [studyTargets, setStudyTargets] = useState({ daily_minutes: 120, weekly_minutes: 0, module_minutes: {} as Record<string, number>, taper: false });
+    const [isSavingTargets, setIsSavingTargets] = useState(false);
+    const [targetsError, setTargetsError] = useState('');
+    const [briefingStyles, setBriefingStyles] = useState<{ tones: string[], languages: string[] }>({ tones: [], languages: [] });
+    const [briefingTone, setBriefingTone] = useState('drill_sergeant');
+    const [briefingLanguage, setBriefingLanguage] = useState('en');
+    const [weeklyReport, setWeeklyReport] = useState({ enabled: false, folder: '', format: 'md' });
+    const [weeklyReportError, setWeeklyReportError] = useState('');
 
     // Update States
     const [checkingUpdate, setCheckingUpdate] = useState(false);
@@ -34,7 +43,18 @@ const Settings = ({ onRefresh }: { onRefresh?: () => void }) => {
                 setReminderTimes(state.user_profile.reminder_times);
             }
             setReminderEnabled(state.user_profile.reminder_enabled !== false);
+            if (state.user_profile.study_targets) {
+                const t = state.user_profile.study_targets;
+                setStudyTargets({ daily_minutes: t.daily_minutes || 120, weekly_minutes: t.weekly_minutes || 0, module_minutes: t.module_minutes || {}, taper: !!t.taper });
+            }
+            setBriefingTone(state.user_profile.briefing_tone || 'drill_sergeant');
+            setBriefingLanguage(state.user_profile.briefing_language || 'en');
+            if (state.user_profile.weekly_report) {
+                const r = state.user_profile.weekly_report;
+                setWeeklyReport({ enabled: !!r.enabled, folder: r.folder || '', format: r.format || 'md' });
+            }
         });
+        GetBriefingStyles().then(setBriefingStyles);
 
         GetAppVersion().then(v => setAppVersion(v));
     }, []);
@@ -55,6 +75,45 @@ const Settings = ({ onRefresh }: { onRefresh?: () => void }) => {
         setTimeout(() => setIsSavingReminders(false), 500);
     };
 
+    const handleSaveTargets = async () => {
+        setIsSavingTargets(true);
+        setTargetsError('');
+        try {
+            await UpdateStudyTargets(main.StudyTargets.createFrom(studyTargets));
+            if (onRefresh) onRefresh();
+        } catch (e: any) {
+            setTargetsError(String(e));
+        }
+        setTimeout(() => setIsSavingTargets(false), 500);
+    };
+
+    const handleBriefingStyle = async (tone: string, language: string) => {
+        setBriefingTone(tone);
+        setBriefingLanguage(language);
+        await UpdateBriefingStyle(tone, language);
+        if (onRefresh) onRefresh();
+    };
+
+    const handleWeeklyReport = async (next: { enabled: boolean, folder: string, format: string }) => {
+        if (next.enabled && !next.folder) {
+            const folder = await ChooseReportFolder();
+            if (!folder) return;
+            next = { ...next, folder };
+        }
+        setWeeklyReportError('');
+        try {
+            await UpdateWeeklyReport(next.enabled, next.folder, next.format);
+            setWeeklyReport(next);
+        } catch (err: any) {
+            setWeeklyReportError(String(err));
+        }
+    };
+
+    const handleChooseReportFolder = async () => {
+        const folder = await ChooseReportFolder();
+        if (folder) handleWeeklyReport({ ...weeklyReport, folder });
+    };
+
     const handleCheckUpdate = async () => {
         setCheckingUpdate(true);
         setUpdateStatus(null);
@@ -261,6 +320,128 @@ const Settings = ({ onRefresh }: { onRefresh?: () => void }) => {
                             </button>
                         </div>
                     </div>
+
+                    {/* Study Targets Card */}
+                    <div className="glass p-6 sm:p-8 rounded-[2.5rem] border-indigo-500/10 space-y-6">
+                        <div className="flex items-center justify-between">
+                            <div className="flex items-center gap-4">
+                                <div className="p-2.5 bg-indigo-500/10 rounded-xl border border-indigo-500/20">
+                                    <Target className="w-5 h-5 text-indigo-400" />
+                                </div>
+                                <div>
+                                    <h3 className="text-base font-black text-white uppercase tracking-tight italic">Study Targets</h3>
+                                    <p className="text-[9px] text-zinc-500 font-bold uppercase tracking-widest italic">Daily Mission</p>
+                                </div>
+                            </div>
+                        </div>
+
+                        <div className="grid grid-cols-2 gap-3">
+                            <label className="p-3 bg-zinc-950 border border-white/5 rounded-xl space-y-1">
+                                <span className="text-[8px] font-black text-zinc-600 uppercase tracking-widest">Daily (min)</span>
+                                <input
+                                    type="number"
+                                    min={0}
+                                    value={studyTargets.daily_minutes}
+                                    onChange={(e) => setStudyTargets({ ...studyTargets, daily_minutes: parseInt(e.target.value) || 0 })}
+                                    className="w-full bg-transparent text-white font-black outline-none text-xs"
+                                />
+                            </label>
+                            <label className="p-3 bg-zinc-950 border border-white/5 rounded-xl space-y-1">
+                                <span className="text-[8px] font-black text-zinc-600 uppercase tracking-widest">Weekly (min)</span>
+                                <input
+                                    type="number"
+                                    min={0}
+                                    placeholder={String(studyTargets.daily_minutes * 7)}
+                                    value={studyTargets.weekly_minutes || ''}
+                                    onChange={(e) => setStudyTargets({ ...studyTargets, weekly_minutes: parseInt(e.target.value) || 0 })}
+                                    className="w-full bg-transparent text-white font-black outline-none text-xs"
+                                />
+                            </label>
+                        </div>
+
+                        <div className="flex items-center justify-between">
+                            <p className="text-[10px] text-zinc-500 leading-relaxed font-bold italic border-l-2 border-indigo-500/20 pl-4">
+                                Taper: scale targets as the test date approaches.
+                            </p>
+                            <div className={`w-12 h-6 rounded-full relative cursor-pointer transition-colors shrink-0 ${studyTargets.taper ? 'bg-indigo-600' : 'bg-zinc-800'}`} onClick={() => setStudyTargets({ ...studyTargets, taper: !studyTargets.taper })}>
+                                <motion.div
+                                    animate={{ x: studyTargets.taper ? 28 : 4 }}
+                                    className="absolute top-1 w-4 h-4 rounded-full bg-white shadow-sm"
+                                />
+                            </div>
+                        </div>
+
+                        {targetsError && (
+                            <p className="text-[9px] font-bold text-red-400 uppercase tracking-widest">{targetsError}</p>
+                        )}
+                        <button
+                            onClick={handleSaveTargets}
+                            disabled={isSavingTargets}
+                            className={`w-full py-4 rounded-xl font-black uppercase tracking-widest text-[9px] flex items-center justify-center gap-3 transition-all ${isSavingTargets ? 'bg-emerald-500/20 text-emerald-400 border border-emerald-500/20' : 'bg-zinc-900 border border-white/5 text-zinc-400 hover:bg-zinc-800 hover:text-white active:scale-95'}`}
+                        >
+                            {isSavingTargets ? 'Targets Synced' : 'Update Targets'}
+                            {!isSavingTargets && <Save className="w-3.5 h-3.5" />}
+                        </button>
+
+                        <div className="grid grid-cols-2 gap-3 pt-6 border-t border-white/5">
+                            <label className="p-3 bg-zinc-950 border border-white/5 rounded-xl space-y-1">
+                                <span className="text-[8px] font-black text-zinc-600 uppercase tracking-widest">Briefing Tone</span>
+                                <select
+                                    value={briefingTone}
+                                    onChange={(e) => handleBriefingStyle(e.target.value, briefingLanguage)}
+                                    className="w-full bg-transparent text-white font-black outline-none text-[10px] uppercase"
+                                >
+                                    {briefingStyles.tones.map(t => <option key={t} value={t} className="bg-zinc-950">{t.replace('_', ' ')}</option>)}
+                                </select>
+                            </label>
+                            <label className="p-3 bg-zinc-950 border border-white/5 rounded-xl space-y-1">
+                                <span className="text-[8px] font-black text-zinc-600 uppercase tracking-widest">Language</span>
+                                <select
+                                    value={briefingLanguage}
+                                    onChange={(e) => handleBriefingStyle(briefingTone, e.target.value)}
+                                    className="w-full bg-transparent text-white font-black outline-none text-[10px] uppercase"
+                                >
+                                    {briefingStyles.languages.map(l => <option key={l} value={l} className="bg-zinc-950">{l}</option>)}
+                                </select>
+                            </label>
+                        </div>
+
+                        <div className="pt-6 border-t border-white/5 space-y-3">
+                            <div className="flex items-center justify-between gap-4">
+                                <div>
+                                    <span className="text-[10px] font-black text-white uppercase tracking-widest">Weekly Report</span>
+                                    <p className="text-[8px] font-bold text-zinc-600 uppercase tracking-widest">Saved every Sunday evening</p>
+                                </div>
+                                <div className={`w-12 h-6 rounded-full relative cursor-pointer transition-colors shrink-0 ${weeklyReport.enabled ? 'bg-indigo-600' : 'bg-zinc-800'}`} onClick={() => handleWeeklyReport({ ...weeklyReport, enabled: !weeklyReport.enabled })}>
+                                    <motion.div
+                                        animate={{ x: weeklyReport.enabled ? 28 : 4 }}
+                                        className="absolute top-1 w-4 h-4 rounded-full bg-white shadow-sm"
+                                    />
+                                </div>
+                            </div>
+                            {weeklyReport.enabled && (
+                                <div className="grid grid-cols-3 gap-3">
+                                    <button
+                                        onClick={handleChooseReportFolder}
+                                        title={weeklyReport.folder}
+                                        className="col-span-2 p-3 bg-zinc-950 border border-white/5 rounded-xl text-left text-[9px] font-bold text-zinc-400 truncate hover:text-white"
+                                    >
+                                        {weeklyReport.folder || 'Choose Folder'}
+                                    </button>
+                                    <select
+                                        value={weeklyReport.format}
+                                        onChange={(e) => handleWeeklyReport({ ...weeklyReport, format: e.target.value })}
+                                        className="p-3 bg-zinc-950 border border-white/5 rounded-xl text-white font-black outline-none text-[10px] uppercase"
+                                    >
+                                        {['md', 'html', 'pdf'].map(f => <option key={f} value={f} className="bg-zinc-950">{f}</option>)}
+                                    </select>
+                                </div>
+                            )}
+                            {weeklyReportError && (
+                                <p className="text-[9px] font-bold text-red-400 uppercase tracking-widest">{weeklyReportError}</p>
+                            )}
+                        </div>
+                    </div>
                 </div>
 
                 {/* Column 3: System Status */}
@@ -2,7 +2,7 @@ import { useState, useEffect } from 'react';
 import { BookOpen, Clock, AlertCircle, ChevronLeft, Save, Layout, List, Info, Trophy, ArrowRight, TrendingUp, Calculator, Target, ExternalLink, Share2, X, Mic, PenTool } from 'lucide-react';
 import { motion } from 'framer-motion';
 import SessionTimer from '../../components/SessionTimer';
-import { GetAppState, UpdateNotes, SetHUDScratchpadVisible, SetSessionCategory } from "../../../wailsjs/go/main/App";
+import { GetAppState, UpdateNotes, SetHUDScratchpadVisible, SetSessionCategory, ConvertRawScore } from "../../../wailsjs/go/main/App";
 import { EventsOn } from '../../../wailsjs/runtime/runtime';
 
 const Reading = ({ onBack, onFinish, category, initialData, onUpdate }: {
@@ -74,49 +74,15 @@ const Reading = ({ onBack, onFinish, category, initialData, onUpdate }: {
         };
     }, []);
 
-    const calculateBand = (raw: number) => {
+    const [currentBand, setCurrentBand] = useState('-');
 
-        if (categoryName.toLowerCase() === 'listening') {
-            if (raw >= 39) return '9.0';
-            if (raw >= 37) return '8.5';
-            if (raw >= 35) return '8.0';
-            if (raw >= 32) return '7.5';
-            if (raw >= 30) return '7.0';
-            if (raw >= 26) return '6.5';
-            if (raw >= 23) return '6.0';
-            if (raw >= 18) return '5.5';
-            if (raw >= 15) return '5.0';
-            return '4.5 or lower';
-        }
-
-        // Reading Academic
-        if (examMode === 'academic') {
-            if (raw >= 39) return '9.0';
-            if (raw >= 37) return '8.5';
-            if (raw >= 35) return '8.0';
-            if (raw >= 33) return '7.5';
-            if (raw >= 30) return '7.0';
-            if (raw >= 27) return '6.5';
-            if (raw >= 23) return '6.0';
-            if (raw >= 19) return '5.5';
-            if (raw >= 15) return '5.0';
-            return '4.5 or lower';
-        }
-
-        // Reading General
-        if (raw >= 40) return '9.0';
-        if (raw >= 39) return '8.5';
-        if (raw >= 37) return '8.0';
-        if (raw >= 36) return '7.5';
-        if (raw >= 34) return '7.0';
-        if (raw >= 32) return '6.5';
-        if (raw >= 30) return '6.0';
-        if (raw >= 27) return '5.5';
-        if (raw >= 23) return '5.0';
-        return '4.5 or lower';
-    };
-
-    const currentBand = calculateBand(rawScore);
+    useEffect(() => {
+        // Conversion tables live in the Go scoring package
+        const section = categoryName.toLowerCase() === 'listening' ? 'listening' : 'reading';
+        ConvertRawScore(`ielts_${examMode}`, section, rawScore)
+            .then((band) => setCurrentBand(band.toFixed(1)))
+            .catch(() => setCurrentBand('-'));
+    }, [rawScore, examMode, categoryName]);
 
     return (
         <div className="flex flex-col h-full bg-zinc-950 overflow-hidden">
@@ -1,7 +1,8 @@
 import { useState, useEffect } from 'react';
 import { motion } from 'framer-motion';
-import { Book, Plus, Send, ChevronLeft, Type, Quote, Clock } from 'lucide-react';
-import { AddVocabulary, SetSessionCategory, UpdateNotes, SetHUDScratchpadVisible } from '../../../wailsjs/go/main/App';
+import { Book, Plus, Send, ChevronLeft, Type, Quote, Clock, Search, Tag } from 'lucide-react';
+import { AddVocabulary, SetSessionCategory, UpdateNotes, SetHUDScratchpadVisible, GetDueReviews, SubmitReview, LookupWord, GetTopics } from '../../../wailsjs/go/main/App';
+import { main } from '../../../wailsjs/go/models';
 import SessionTimer from '../../components/SessionTimer';
 import { EventsOn } from '../../../wailsjs/runtime/runtime';
 
@@ -46,12 +47,49 @@ const Vocabulary = ({ onBack, onFinish, initialData, onUpdate }: {
         };
     }, []);
     const [saved, setSaved] = useState(false);
+    const [mergedInto, setMergedInto] = useState('');
     const [recentForges, setRecentForges] = useState<any[]>([]);
+    const [reviewQueue, setReviewQueue] = useState<main.VocabItem[]>([]);
+    const [revealed, setRevealed] = useState(false);
+    const [lookup, setLookup] = useState<main.LookupResult | null>(null);
+    const [topic, setTopic] = useState('');
+    const [tags, setTags] = useState('');
+    const [topics, setTopics] = useState<string[]>([]);
+
+    useEffect(() => {
+        GetTopics().then(list => setTopics(list || []));
+    }, []);
+
+    const handleLookup = async () => {
+        if (!word.trim()) return;
+        const result = await LookupWord(word);
+        setLookup(result);
+        if (!result.found) return;
+        // Only fill what the user has not typed themselves
+        if (!definition) setDefinition(result.def);
+        if (!sentences) setSentences(result.sentences);
+    };
+
+    const loadReviews = () => GetDueReviews().then(items => setReviewQueue(items || []));
+
+    useEffect(() => {
+        loadReviews();
+    }, []);
+
+    const handleGrade = async (grade: number) => {
+        const [current, ...rest] = reviewQueue;
+        if (!current) return;
+        await SubmitReview(current.id, grade);
+        setRevealed(false);
+        // Words graded Again come back at the end of this session
+        setReviewQueue(grade === 1 ? [...rest, current] : rest);
+    };
 
     const handleSave = async () => {
         if (!word) return;
         const entry = { word, id: Date.now() };
-        await AddVocabulary(word, definition, sentences);
+        const result = await AddVocabulary(word, definition, sentences, topic, [tags]);
+        setMergedInto(result.merged ? result.item.word : '');
 
         setRecentForges(prev => [entry, ...prev].slice(0, 3));
         setSaved(true);
@@ -60,6 +98,7 @@ const Vocabulary = ({ onBack, onFinish, initialData, onUpdate }: {
             setWord('');
             setDefinition('');
             setSentences('');
+            setTags('');
         }, 1500);
     };
 
@@ -100,12 +139,37 @@ const Vocabulary = ({ onBack, onFinish, initialData, onUpdate }: {
 
                         <div className="space-y-4">
                             <label className="text-xs font-bold text-zinc-500 uppercase tracking-widest">New Term</label>
-                            <input
-                                value={word}
-                                onChange={(e) => setWord(e.target.value)}
-                                placeholder="e.g., Obsequious"
-                                className="w-full bg-zinc-900 border border-zinc-800 rounded-xl p-4 text-xl font-bold text-white outline-none focus:border-amber-500/50 transition-all placeholder:text-zinc-700"
-                            />
+                            <div className="flex gap-2">
+                                <input
+                                    value={word}
+                                    onChange={(e) => { setWord(e.target.value); setLookup(null); }}
+                                    onKeyDown={(e) => e.key === 'Enter' && handleLookup()}
+                                    placeholder="e.g., Obsequious"
+                                    className="flex-1 min-w-0 bg-zinc-900 border border-zinc-800 rounded-xl p-4 text-xl font-bold text-white outline-none focus:border-amber-500/50 transition-all placeholder:text-zinc-700"
+                                />
+                                <button
+                                    onClick={handleLookup}
+                                    title="Look up in the offline dictionary"
+                                    className="px-4 rounded-xl bg-zinc-900 border border-zinc-800 text-zinc-500 hover:text-amber-400 hover:border-amber-500/30 transition-all"
+                                >
+                                    <Search className="w-4 h-4" />
+                                </button>
+                            </div>
+                            {lookup && (
+                                lookup.found ? (
+                                    <div className="p-3 bg-zinc-900/50 border border-white/5 rounded-xl space-y-2">
+                                        {lookup.senses.map((sense, i) => (
+                                            <div key={i} className="text-[11px] text-zinc-400 leading-relaxed">
+                                                <span className="font-black text-amber-500/70 uppercase text-[9px] tracking-widest mr-2">{sense.part_of_speech}</span>
+                                                {sense.definition}
+                                                {sense.synonyms.length > 0 && <span className="block text-zinc-600 italic">≈ {sense.synonyms.join(', ')}</span>}
+                                            </div>
+                                        ))}
+                                    </div>
+                                ) : (
+                                    <p className="text-[9px] font-bold text-zinc-600 uppercase tracking-widest">Not in the offline dictionary</p>
+                                )
+                            )}
                             <input
                                 value={definition}
                                 onChange={(e) => setDefinition(e.target.value)}
@@ -126,12 +190,34 @@ const Vocabulary = ({ onBack, onFinish, initialData, onUpdate }: {
                             />
                         </div>
 
+                        <div className="space-y-4">
+                            <label className="text-xs font-bold text-zinc-500 uppercase tracking-widest flex items-center gap-2">
+                                <Tag className="w-3 h-3" /> Topic &amp; Tags
+                            </label>
+                            <div className="flex gap-2">
+                                <select
+                                    value={topic}
+                                    onChange={(e) => setTopic(e.target.value)}
+                                    className="w-36 bg-zinc-900/50 border border-zinc-800 rounded-xl p-3 text-sm text-zinc-300 outline-none focus:border-amber-500/30 transition-all capitalize"
+                                >
+                                    <option value="" className="bg-zinc-950">Auto</option>
+                                    {topics.map(t => <option key={t} value={t} className="bg-zinc-950">{t}</option>)}
+                                </select>
+                                <input
+                                    value={tags}
+                                    onChange={(e) => setTags(e.target.value)}
+                                    placeholder="ielts, band-7..."
+                                    className="flex-1 min-w-0 bg-zinc-900/50 border border-zinc-800 rounded-xl p-3 text-sm text-zinc-300 outline-none focus:border-amber-500/30 transition-all placeholder:text-zinc-700"
+                                />
+                            </div>
+                        </div>
+
                         <button
                             onClick={handleSave}
                             className={`w-full py-4 rounded-xl font-bold uppercase tracking-widest text-xs flex items-center justify-center gap-2 transition-all ${saved ? 'bg-emerald-500 text-white shadow-[0_0_20px_rgba(16,185,129,0.2)]' : 'bg-amber-600 hover:bg-amber-500 text-white shadow-xl shadow-amber-900/10'
                                 }`}
                         >
-                            {saved ? 'Victory! Entry Forged' : 'Forge Entry'}
+                            {saved ? (mergedInto ? `Merged Into "${mergedInto}"` : 'Victory! Entry Forged') : 'Forge Entry'}
                             <Plus className="w-4 h-4" />
                         </button>
                     </div>
@@ -210,6 +296,47 @@ const Vocabulary = ({ onBack, onFinish, initialData, onUpdate }: {
                                 </div>
                                 <div className="absolute -bottom-20 -right-20 w-80 h-80 bg-amber-500/5 blur-[120px] rounded-full group-hover:bg-amber-500/10 transition-all duration-700" />
                             </motion.div>
+                        ) : reviewQueue.length > 0 ? (
+                            <div className="glass rounded-[3rem] p-10 sm:p-16 space-y-10 border border-white/5">
+                                <div className="flex items-center justify-between">
+                                    <div className="flex items-center gap-2">
+                                        <div className="w-8 h-1 bg-amber-500 rounded-full" />
+                                        <span className="text-[9px] font-black text-amber-500/50 uppercase tracking-widest">Review Queue</span>
+                                    </div>
+                                    <span className="text-[9px] font-black text-zinc-600 uppercase tracking-widest">{reviewQueue.length} left</span>
+                                </div>
+                                <h2 className="text-5xl sm:text-7xl font-black italic tracking-tighter text-white uppercase break-words leading-none">
+                                    {reviewQueue[0].word}
+                                </h2>
+                                {revealed ? (
+                                    <div className="space-y-8">
+                                        <div className="space-y-4">
+                                            <p className="text-xl font-bold text-zinc-400 italic">{reviewQueue[0].def}</p>
+                                            {(reviewQueue[0].sentences || '').split('\n').filter(Boolean).map((line: string, i: number) => (
+                                                <p key={i} className="text-lg text-zinc-300 leading-relaxed font-serif italic">{line}</p>
+                                            ))}
+                                        </div>
+                                        <div className="grid grid-cols-4 gap-3">
+                                            {[[1, 'Again', 'hover:bg-red-500/20 hover:text-red-400'], [2, 'Hard', 'hover:bg-amber-500/20 hover:text-amber-400'], [3, 'Good', 'hover:bg-emerald-500/20 hover:text-emerald-400'], [4, 'Easy', 'hover:bg-indigo-500/20 hover:text-indigo-400']].map(([grade, label, hover]) => (
+                                                <button
+                                                    key={grade}
+                                                    onClick={() => handleGrade(grade as number)}
+                                                    className={`py-4 rounded-xl bg-zinc-900 border border-white/5 text-[10px] font-black uppercase tracking-widest text-zinc-400 transition-all ${hover}`}
+                                                >
+                                                    {label}
+                                                </button>
+                                            ))}
+                                        </div>
+                                    </div>
+                                ) : (
+                                    <button
+                                        onClick={() => setRevealed(true)}
+                                        className="w-full py-4 rounded-xl bg-amber-600 hover:bg-amber-500 text-white font-bold uppercase tracking-widest text-xs transition-all"
+                                    >
+                                        Reveal
+                                    </button>
+                                )}
+                            </div>
                         ) : (
                             <div className="h-[400px] flex flex-col items-center justify-center text-center space-y-6 opacity-20">
                                 <div className="w-24 h-24 rounded-[2.5rem] bg-zinc-900 flex items-center justify-center">
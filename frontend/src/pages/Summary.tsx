@@ -1,12 +1,14 @@
 import { useState } from 'react';
 import { motion, AnimatePresence } from 'framer-motion';
 import { MessageSquare, Star, Trophy, ArrowRight, ShieldCheck, Zap, BarChart3, Home } from 'lucide-react';
-import { UpdateLastLogSession } from "../../wailsjs/go/main/App";
+import { UpdateLog } from "../../wailsjs/go/main/App";
+import { main } from "../../wailsjs/go/models";
 
 interface SummaryProps {
     lastSession: {
         category: string | null;
         duration: number;
+        logId?: string;
         data: any;
     };
     onComplete: () => void;
@@ -21,12 +23,28 @@ const Summary = ({ lastSession, onComplete, onStartNew, todayMinutes }: SummaryP
     const [score, setScore] = useState('');
     const [homework, setHomework] = useState('');
     const [isSaving, setIsSaving] = useState(false);
+    const [saveError, setSaveError] = useState('');
 
     const handleSubmit = async () => {
+        if (!lastSession.logId) {
+            setSaveError('The session is still being saved. Try again in a moment.');
+            return;
+        }
         setIsSaving(true);
-        await UpdateLastLogSession(reflection, parseFloat(score) || 0, homework, learnings);
-        setIsSaving(false);
-        onComplete();
+        setSaveError('');
+        try {
+            await UpdateLog(lastSession.logId, main.LogPatch.createFrom({
+                reflection,
+                score: parseFloat(score) || 0,
+                homework,
+                learnings
+            }));
+            onComplete();
+        } catch (err) {
+            setSaveError(String(err));
+        } finally {
+            setIsSaving(false);
+        }
     };
 
     return (
@@ -174,6 +192,9 @@ const Summary = ({ lastSession, onComplete, onStartNew, todayMinutes }: SummaryP
                                     {isSaving ? 'Syncing...' : 'Commit Growth'}
                                     <Trophy className="w-5 h-5 sm:w-6 sm:h-6" />
                                 </button>
+                                {saveError && (
+                                    <p className="text-center text-xs sm:text-sm font-bold text-red-400">{saveError}</p>
+                                )}
                             </motion.div>
                         )}
                     </AnimatePresence>
@@ -4,45 +4,97 @@ import {main} from '../models';
 
 export function AddCredits(arg1:number):Promise<void>;
 
-export function AddVocabulary(arg1:string,arg2:string,arg3:string):Promise<void>;
+export function AddVocabulary(arg1:string,arg2:string,arg3:string,arg4:string,arg5:Array<string>):Promise<main.VocabAddResult>;
+
+export function AnalyzeLogVocabulary(arg1:string):Promise<main.LogVocabulary>;
 
 export function CheckUpdate():Promise<main.UpdateInfo>;
 
+export function ChooseReportFolder():Promise<string>;
+
 export function CompleteSetup(arg1:string,arg2:string):Promise<void>;
 
 export function CompleteTutorial():Promise<void>;
 
+export function ConvertRawScore(arg1:string,arg2:string,arg3:number):Promise<number>;
+
 export function DeleteLog(arg1:string):Promise<void>;
 
-export function DeleteVocabulary(arg1:string):Promise<void>;
+export function DeleteVocabularies(arg1:Array<string>):Promise<main.VocabBulkResult>;
+
+export function DeleteVocabulary(arg1:string):Promise<boolean>;
 
 export function DownloadUpdate(arg1:string,arg2:string):Promise<string>;
 
 export function ExportData():Promise<void>;
 
+export function ExportReport(arg1:string,arg2:string):Promise<string>;
+
+export function ExportVocabulary(arg1:string):Promise<string>;
+
+export function FindDuplicateVocabulary():Promise<Array<main.VocabDuplicates>>;
+
 export function GetAppState():Promise<main.AppState>;
 
 export function GetAppVersion():Promise<string>;
 
+export function GetBriefingStyles():Promise<main.BriefingStyles>;
+
 export function GetConsistencyPhase():Promise<string>;
 
+export function GetConsistencyReport():Promise<main.ConsistencyReport>;
+
+export function GetDriftReport():Promise<main.DriftReport>;
+
+export function GetDueReviews():Promise<Array<main.VocabItem>>;
+
 export function GetEngressBriefing():Promise<string>;
 
-export function GetState():Promise<main.AppState>;
+export function GetLog(arg1:string):Promise<main.DailyLog>;
+
+export function GetReport(arg1:string,arg2:string):Promise<main.Report>;
+
+export function GetReviewStats():Promise<main.ReviewStats>;
+
+export function GetRuntimeState():Promise<main.RuntimeState>;
+
+export function GetScoreHistory():Promise<main.ScoreHistory>;
+
+export function GetStudyPlan():Promise<main.StudyPlan>;
+
+export function GetTargetProgress():Promise<main.TargetProgress>;
+
+export function GetTopics():Promise<Array<string>>;
+
+export function GetVocabularyCoverage():Promise<main.VocabularyCoverage>;
+
+export function GetVocabularyUsage():Promise<main.VocabularyUsage>;
+
+export function GetWeaknesses():Promise<Array<main.Weakness>>;
 
 export function Greet(arg1:string):Promise<string>;
 
-export function LoadState():Promise<main.AppState>;
+export function ImportVocabulary(arg1:string,arg2:main.VocabFieldMapping):Promise<main.VocabImportResult>;
+
+export function ListLogs(arg1:main.LogFilter):Promise<main.LogPage>;
+
+export function LogSession(arg1:main.SessionInput):Promise<string>;
+
+export function LookupWord(arg1:string):Promise<main.LookupResult>;
 
-export function LogSession(arg1:string,arg2:string,arg3:number,arg4:string,arg5:number,arg6:string,arg7:string,arg8:string,arg9:string):Promise<void>;
+export function MergeDuplicateVocabulary():Promise<main.VocabBulkResult>;
+
+export function MergeVocabulary(arg1:Array<string>):Promise<main.VocabItem>;
 
 export function Notify(arg1:string,arg2:string):Promise<void>;
 
+export function PreviewVocabularyImport():Promise<main.VocabImportPreview>;
+
 export function Quit():Promise<void>;
 
-export function ResetAppData():Promise<string>;
+export function RegeneratePlan():Promise<main.StudyPlan>;
 
-export function SaveState(arg1:main.AppState):Promise<void>;
+export function ResetAppData():Promise<string>;
 
 export function SetHUDScratchpadVisible(arg1:boolean):Promise<void>;
 
@@ -50,13 +102,23 @@ export function SetPauseState(arg1:boolean):Promise<void>;
 
 export function SetSessionCategory(arg1:string):Promise<void>;
 
-export function SetState(arg1:main.AppState):Promise<void>;
+export function SetVocabularyTopic(arg1:string,arg2:string,arg3:Array<string>):Promise<void>;
 
 export function ShowWindow():Promise<void>;
 
 export function StartScheduler():Promise<void>;
 
-export function UpdateLastLogSession(arg1:string,arg2:number,arg3:string,arg4:string):Promise<void>;
+export function SubmitReview(arg1:string,arg2:number):Promise<main.ReviewState>;
+
+export function UpdateBackupsToKeep(arg1:number):Promise<void>;
+
+export function UpdateBriefingStyle(arg1:string,arg2:string):Promise<void>;
+
+export function UpdateDriftSettings(arg1:main.DriftSettings):Promise<void>;
+
+export function UpdateExamType(arg1:string,arg2:number):Promise<void>;
+
+export function UpdateLog(arg1:string,arg2:main.LogPatch):Promise<main.DailyLog>;
 
 export function UpdateNotes(arg1:string):Promise<void>;
 
@@ -64,6 +126,14 @@ export function UpdateProfileName(arg1:string):Promise<void>;
 
 export function UpdateReminders(arg1:boolean,arg2:Array<string>):Promise<void>;
 
+export function UpdateStudyTargets(arg1:main.StudyTargets):Promise<void>;
+
 export function UpdateTestDate(arg1:string):Promise<void>;
 
+export function UpdateTimezone(arg1:string):Promise<void>;
+
 export function UpdateTrayTime(arg1:string):Promise<void>;
+
+export function UpdateVocabulary(arg1:string,arg2:main.VocabPatch):Promise<main.VocabItem>;
+
+export function UpdateWeeklyReport(arg1:boolean,arg2:string,arg3:string):Promise<void>;
@@ -6,14 +6,22 @@ export function AddCredits(arg1) {
   return window['go']['main']['App']['AddCredits'](arg1);
 }
 
-export function AddVocabulary(arg1, arg2, arg3) {
-  return window['go']['main']['App']['AddVocabulary'](arg1, arg2, arg3);
+export function AddVocabulary(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['AddVocabulary'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function AnalyzeLogVocabulary(arg1) {
+  return window['go']['main']['App']['AnalyzeLogVocabulary'](arg1);
 }
 
 export function CheckUpdate() {
   return window['go']['main']['App']['CheckUpdate']();
 }
 
+export function ChooseReportFolder() {
+  return window['go']['main']['App']['ChooseReportFolder']();
+}
+
 export function CompleteSetup(arg1, arg2) {
   return window['go']['main']['App']['CompleteSetup'](arg1, arg2);
 }
@@ -22,10 +30,18 @@ export function CompleteTutorial() {
   return window['go']['main']['App']['CompleteTutorial']();
 }
 
+export function ConvertRawScore(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ConvertRawScore'](arg1, arg2, arg3);
+}
+
 export function DeleteLog(arg1) {
   return window['go']['main']['App']['DeleteLog'](arg1);
 }
 
+export function DeleteVocabularies(arg1) {
+  return window['go']['main']['App']['DeleteVocabularies'](arg1);
+}
+
 export function DeleteVocabulary(arg1) {
   return window['go']['main']['App']['DeleteVocabulary'](arg1);
 }
@@ -38,6 +54,18 @@ export function ExportData() {
   return window['go']['main']['App']['ExportData']();
 }
 
+export function ExportReport(arg1, arg2) {
+  return window['go']['main']['App']['ExportReport'](arg1, arg2);
+}
+
+export function ExportVocabulary(arg1) {
+  return window['go']['main']['App']['ExportVocabulary'](arg1);
+}
+
+export function FindDuplicateVocabulary() {
+  return window['go']['main']['App']['FindDuplicateVocabulary']();
+}
+
 export function GetAppState() {
   return window['go']['main']['App']['GetAppState']();
 }
@@ -46,44 +74,120 @@ export function GetAppVersion() {
   return window['go']['main']['App']['GetAppVersion']();
 }
 
+export function GetBriefingStyles() {
+  return window['go']['main']['App']['GetBriefingStyles']();
+}
+
 export function GetConsistencyPhase() {
   return window['go']['main']['App']['GetConsistencyPhase']();
 }
 
+export function GetConsistencyReport() {
+  return window['go']['main']['App']['GetConsistencyReport']();
+}
+
+export function GetDriftReport() {
+  return window['go']['main']['App']['GetDriftReport']();
+}
+
+export function GetDueReviews() {
+  return window['go']['main']['App']['GetDueReviews']();
+}
+
 export function GetEngressBriefing() {
   return window['go']['main']['App']['GetEngressBriefing']();
 }
 
-export function GetState() {
-  return window['go']['main']['App']['GetState']();
+export function GetLog(arg1) {
+  return window['go']['main']['App']['GetLog'](arg1);
+}
+
+export function GetReport(arg1, arg2) {
+  return window['go']['main']['App']['GetReport'](arg1, arg2);
+}
+
+export function GetReviewStats() {
+  return window['go']['main']['App']['GetReviewStats']();
+}
+
+export function GetRuntimeState() {
+  return window['go']['main']['App']['GetRuntimeState']();
+}
+
+export function GetScoreHistory() {
+  return window['go']['main']['App']['GetScoreHistory']();
+}
+
+export function GetStudyPlan() {
+  return window['go']['main']['App']['GetStudyPlan']();
+}
+
+export function GetTargetProgress() {
+  return window['go']['main']['App']['GetTargetProgress']();
+}
+
+export function GetTopics() {
+  return window['go']['main']['App']['GetTopics']();
+}
+
+export function GetVocabularyCoverage() {
+  return window['go']['main']['App']['GetVocabularyCoverage']();
+}
+
+export function GetVocabularyUsage() {
+  return window['go']['main']['App']['GetVocabularyUsage']();
+}
+
+export function GetWeaknesses() {
+  return window['go']['main']['App']['GetWeaknesses']();
 }
 
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
-export function LoadState() {
-  return window['go']['main']['App']['LoadState']();
+export function ImportVocabulary(arg1, arg2) {
+  return window['go']['main']['App']['ImportVocabulary'](arg1, arg2);
 }
 
-export function LogSession(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9) {
-  return window['go']['main']['App']['LogSession'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9);
+export function ListLogs(arg1) {
+  return window['go']['main']['App']['ListLogs'](arg1);
+}
+
+export function LogSession(arg1) {
+  return window['go']['main']['App']['LogSession'](arg1);
+}
+
+export function LookupWord(arg1) {
+  return window['go']['main']['App']['LookupWord'](arg1);
+}
+
+export function MergeDuplicateVocabulary() {
+  return window['go']['main']['App']['MergeDuplicateVocabulary']();
+}
+
+export function MergeVocabulary(arg1) {
+  return window['go']['main']['App']['MergeVocabulary'](arg1);
 }
 
 export function Notify(arg1, arg2) {
   return window['go']['main']['App']['Notify'](arg1, arg2);
 }
 
+export function PreviewVocabularyImport() {
+  return window['go']['main']['App']['PreviewVocabularyImport']();
+}
+
 export function Quit() {
   return window['go']['main']['App']['Quit']();
 }
 
-export function ResetAppData() {
-  return window['go']['main']['App']['ResetAppData']();
+export function RegeneratePlan() {
+  return window['go']['main']['App']['RegeneratePlan']();
 }
 
-export function SaveState(arg1) {
-  return window['go']['main']['App']['SaveState'](arg1);
+export function ResetAppData() {
+  return window['go']['main']['App']['ResetAppData']();
 }
 
 export function SetHUDScratchpadVisible(arg1) {
@@ -98,8 +202,8 @@ export function SetSessionCategory(arg1) {
   return window['go']['main']['App']['SetSessionCategory'](arg1);
 }
 
-export function SetState(arg1) {
-  return window['go']['main']['App']['SetState'](arg1);
+export function SetVocabularyTopic(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetVocabularyTopic'](arg1, arg2, arg3);
 }
 
 export function ShowWindow() {
@@ -110,8 +214,28 @@ export function StartScheduler() {
   return window['go']['main']['App']['StartScheduler']();
 }
 
-export function UpdateLastLogSession(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['UpdateLastLogSession'](arg1, arg2, arg3, arg4);
+export function SubmitReview(arg1, arg2) {
+  return window['go']['main']['App']['SubmitReview'](arg1, arg2);
+}
+
+export function UpdateBackupsToKeep(arg1) {
+  return window['go']['main']['App']['UpdateBackupsToKeep'](arg1);
+}
+
+export function UpdateBriefingStyle(arg1, arg2) {
+  return window['go']['main']['App']['UpdateBriefingStyle'](arg1, arg2);
+}
+
+export function UpdateDriftSettings(arg1) {
+  return window['go']['main']['App']['UpdateDriftSettings'](arg1);
+}
+
+export function UpdateExamType(arg1, arg2) {
+  return window['go']['main']['App']['UpdateExamType'](arg1, arg2);
+}
+
+export function UpdateLog(arg1, arg2) {
+  return window['go']['main']['App']['UpdateLog'](arg1, arg2);
 }
 
 export function UpdateNotes(arg1) {
@@ -126,10 +250,26 @@ export function UpdateReminders(arg1, arg2) {
   return window['go']['main']['App']['UpdateReminders'](arg1, arg2);
 }
 
+export function UpdateStudyTargets(arg1) {
+  return window['go']['main']['App']['UpdateStudyTargets'](arg1);
+}
+
 export function UpdateTestDate(arg1) {
   return window['go']['main']['App']['UpdateTestDate'](arg1);
 }
 
+export function UpdateTimezone(arg1) {
+  return window['go']['main']['App']['UpdateTimezone'](arg1);
+}
+
 export function UpdateTrayTime(arg1) {
   return window['go']['main']['App']['UpdateTrayTime'](arg1);
 }
+
+export function UpdateVocabulary(arg1, arg2) {
+  return window['go']['main']['App']['UpdateVocabulary'](arg1, arg2);
+}
+
+export function UpdateWeeklyReport(arg1, arg2, arg3) {
+  return window['go']['main']['App']['UpdateWeeklyReport'](arg1, arg2, arg3);
+}
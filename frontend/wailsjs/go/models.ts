@@ -7,6 +7,9 @@ export namespace main {
 	    sentences: string;
 	    date_added: string;
 	    time: string;
+	    topic: string;
+	    tags: string[];
+	    review: ReviewState;
 	
 	    static createFrom(source: any = {}) {
 	        return new VocabItem(source);
@@ -20,8 +23,30 @@ export namespace main {
 	        this.sentences = source["sentences"];
 	        this.date_added = source["date_added"];
 	        this.time = source["time"];
+	        this.topic = source["topic"];
+	        this.tags = source["tags"];
+	        this.review = this.convertValues(source["review"], ReviewState);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	
 	export class DailyLog {
 	    id: string;
 	    date: string;
@@ -35,6 +60,12 @@ export namespace main {
 	    source_url: string;
 	    screenshot: string;
 	    time: string;
+	    attachments: string[];
+	    scores: Scores;
+	    exam_type: string;
+	    sub_task: string;
+	    started_at: string;
+	    ended_at: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new DailyLog(source);
@@ -54,8 +85,33 @@ export namespace main {
 	        this.source_url = source["source_url"];
 	        this.screenshot = source["screenshot"];
 	        this.time = source["time"];
+	        this.attachments = source["attachments"];
+	        this.scores = this.convertValues(source["scores"], Scores);
+	        this.exam_type = source["exam_type"];
+	        this.sub_task = source["sub_task"];
+	        this.started_at = source["started_at"];
+	        this.ended_at = source["ended_at"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	
 	export class UserProfile {
 	    name: string;
 	    test_date: string;
@@ -65,6 +121,14 @@ export namespace main {
 	    reminder_times: string[];
 	    reminder_enabled: boolean;
 	    tutorial_seen: boolean;
+	    backups_to_keep: number;
+	    timezone: string;
+	    exam_type: string;
+	    study_targets: StudyTargets;
+	    drift: DriftSettings;
+	    briefing_tone: string;
+	    briefing_language: string;
+	    weekly_report: WeeklyReportSettings;
 	
 	    static createFrom(source: any = {}) {
 	        return new UserProfile(source);
@@ -80,12 +144,41 @@ export namespace main {
 	        this.reminder_times = source["reminder_times"];
 	        this.reminder_enabled = source["reminder_enabled"];
 	        this.tutorial_seen = source["tutorial_seen"];
+	        this.backups_to_keep = source["backups_to_keep"];
+	        this.timezone = source["timezone"];
+	        this.exam_type = source["exam_type"];
+	        this.study_targets = this.convertValues(source["study_targets"], StudyTargets);
+	        this.drift = this.convertValues(source["drift"], DriftSettings);
+	        this.briefing_tone = source["briefing_tone"];
+	        this.briefing_language = source["briefing_language"];
+	        this.weekly_report = this.convertValues(source["weekly_report"], WeeklyReportSettings);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	
 	export class AppState {
+	    schema_version: number;
 	    user_profile: UserProfile;
 	    daily_logs: DailyLog[];
 	    vocabulary: VocabItem[];
+	    study_plan?: StudyPlan;
 	
 	    static createFrom(source: any = {}) {
 	        return new AppState(source);
@@ -93,9 +186,11 @@ export namespace main {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schema_version = source["schema_version"];
 	        this.user_profile = this.convertValues(source["user_profile"], UserProfile);
 	        this.daily_logs = this.convertValues(source["daily_logs"], DailyLog);
 	        this.vocabulary = this.convertValues(source["vocabulary"], VocabItem);
+	        this.study_plan = this.convertValues(source["study_plan"], StudyPlan);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -137,6 +232,1503 @@ export namespace main {
 	        this.error = source["error"];
 	    }
 	}
+	export class RuntimeState {
+	    is_paused: boolean;
+	    current_category: string;
+	    current_time: string;
+	    hud_scratchpad_visible: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RuntimeState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.is_paused = source["is_paused"];
+	        this.current_category = source["current_category"];
+	        this.current_time = source["current_time"];
+	        this.hud_scratchpad_visible = source["hud_scratchpad_visible"];
+	    }
+	}
+	export class LogFilter {
+	    module: string;
+	    from: string;
+	    to: string;
+	    query: string;
+	    offset: number;
+	    limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.query = source["query"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
+	}
+	export class LogPage {
+	    logs: DailyLog[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.logs = this.convertValues(source["logs"], DailyLog);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LogPatch {
+	    module?: string;
+	    duration?: number;
+	    score?: number;
+	    reflection?: string;
+	    homework?: string;
+	    learnings?: string;
+	    content?: string;
+	    source_url?: string;
+	    sub_task?: string;
+	    scores?: Scores;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogPatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.duration = source["duration"];
+	        this.score = source["score"];
+	        this.reflection = source["reflection"];
+	        this.homework = source["homework"];
+	        this.learnings = source["learnings"];
+	        this.content = source["content"];
+	        this.source_url = source["source_url"];
+	        this.sub_task = source["sub_task"];
+	        this.scores = this.convertValues(source["scores"], Scores);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SessionInput {
+	    module: string;
+	    duration: number;
+	    score: number;
+	    reflection: string;
+	    homework: string;
+	    learnings: string;
+	    content: string;
+	    source_url: string;
+	    screenshot: string;
+	    scores: Scores;
+	    exam_type?: string;
+	    sub_task?: string;
+	    started_at?: string;
+	    ended_at?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.duration = source["duration"];
+	        this.score = source["score"];
+	        this.reflection = source["reflection"];
+	        this.homework = source["homework"];
+	        this.learnings = source["learnings"];
+	        this.content = source["content"];
+	        this.source_url = source["source_url"];
+	        this.screenshot = source["screenshot"];
+	        this.scores = this.convertValues(source["scores"], Scores);
+	        this.exam_type = source["exam_type"];
+	        this.sub_task = source["sub_task"];
+	        this.started_at = source["started_at"];
+	        this.ended_at = source["ended_at"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Scores {
+	    reading: number;
+	    writing: number;
+	    listening: number;
+	    speaking: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Scores(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.reading = source["reading"];
+	        this.writing = source["writing"];
+	        this.listening = source["listening"];
+	        this.speaking = source["speaking"];
+	    }
+	}
+	export class ScorePoint {
+	    date: string;
+	    score: number;
+	    log_id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScorePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.score = source["score"];
+	        this.log_id = source["log_id"];
+	    }
+	}
+	export class ScoreHistory {
+	    exam_type: string;
+	    listening: ScorePoint[];
+	    reading: ScorePoint[];
+	    writing: ScorePoint[];
+	    speaking: ScorePoint[];
+	    overall: ScorePoint[];
+	    recent: Scores;
+	    projected: number;
+	    missing_skills: string[];
+	    target: number;
+	    gap: number;
+	    on_track: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreHistory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.exam_type = source["exam_type"];
+	        this.listening = this.convertValues(source["listening"], ScorePoint);
+	        this.reading = this.convertValues(source["reading"], ScorePoint);
+	        this.writing = this.convertValues(source["writing"], ScorePoint);
+	        this.speaking = this.convertValues(source["speaking"], ScorePoint);
+	        this.overall = this.convertValues(source["overall"], ScorePoint);
+	        this.recent = this.convertValues(source["recent"], Scores);
+	        this.projected = source["projected"];
+	        this.missing_skills = source["missing_skills"];
+	        this.target = source["target"];
+	        this.gap = source["gap"];
+	        this.on_track = source["on_track"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class StudyTargets {
+	    daily_minutes: number;
+	    weekly_minutes: number;
+	    module_minutes: Record<string, number>;
+	    taper: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new StudyTargets(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.daily_minutes = source["daily_minutes"];
+	        this.weekly_minutes = source["weekly_minutes"];
+	        this.module_minutes = source["module_minutes"];
+	        this.taper = source["taper"];
+	    }
+	}
+	export class ModuleProgress {
+	    module: string;
+	    target: number;
+	    logged: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModuleProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.target = source["target"];
+	        this.logged = source["logged"];
+	    }
+	}
+	export class TargetProgress {
+	    day: string;
+	    daily_target: number;
+	    daily_logged: number;
+	    weekly_target: number;
+	    weekly_logged: number;
+	    modules: ModuleProgress[];
+	    taper_factor: number;
+	    days_until_test: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TargetProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.day = source["day"];
+	        this.daily_target = source["daily_target"];
+	        this.daily_logged = source["daily_logged"];
+	        this.weekly_target = source["weekly_target"];
+	        this.weekly_logged = source["weekly_logged"];
+	        this.modules = this.convertValues(source["modules"], ModuleProgress);
+	        this.taper_factor = source["taper_factor"];
+	        this.days_until_test = source["days_until_test"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PlannedSession {
+	    module: string;
+	    minutes: number;
+	    logged: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlannedSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.minutes = source["minutes"];
+	        this.logged = source["logged"];
+	    }
+	}
+	export class PlanDay {
+	    date: string;
+	    mock: boolean;
+	    sessions: PlannedSession[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.mock = source["mock"];
+	        this.sessions = this.convertValues(source["sessions"], PlannedSession);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class StudyPlan {
+	    generated_at: string;
+	    rebalanced_at: string;
+	    checked_through: string;
+	    exam_type: string;
+	    test_date: string;
+	    target_score: number;
+	    weights: Record<string, number>;
+	    days: PlanDay[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StudyPlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generated_at = source["generated_at"];
+	        this.rebalanced_at = source["rebalanced_at"];
+	        this.checked_through = source["checked_through"];
+	        this.exam_type = source["exam_type"];
+	        this.test_date = source["test_date"];
+	        this.target_score = source["target_score"];
+	        this.weights = source["weights"];
+	        this.days = this.convertValues(source["days"], PlanDay);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class WeaknessSignal {
+	    key: string;
+	    score?: number;
+	    from?: number;
+	    target?: number;
+	    gap?: number;
+	    minutes?: number;
+	    total?: number;
+	    days?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeaknessSignal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.score = source["score"];
+	        this.from = source["from"];
+	        this.target = source["target"];
+	        this.gap = source["gap"];
+	        this.minutes = source["minutes"];
+	        this.total = source["total"];
+	        this.days = source["days"];
+	    }
+	}
+	export class Weakness {
+	    module: string;
+	    score: number;
+	    reasons: string[];
+	    signals: WeaknessSignal[];
+	    recent_score: number;
+	    section_target: number;
+	    minutes: number;
+	    days_since_last: number;
+	    trend: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Weakness(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.score = source["score"];
+	        this.reasons = source["reasons"];
+	        this.signals = this.convertValues(source["signals"], WeaknessSignal);
+	        this.recent_score = source["recent_score"];
+	        this.section_target = source["section_target"];
+	        this.minutes = source["minutes"];
+	        this.days_since_last = source["days_since_last"];
+	        this.trend = source["trend"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModuleRecency {
+	    module: string;
+	    days_since: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModuleRecency(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.days_since = source["days_since"];
+	    }
+	}
+	export class ConsistencyReport {
+	    phase: string;
+	    current_streak: number;
+	    longest_streak: number;
+	    last_active_day: string;
+	    active_days_7: number;
+	    active_days_30: number;
+	    active_ratio_7: number;
+	    active_ratio_30: number;
+	    reviews_today: number;
+	    modules: ModuleRecency[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ConsistencyReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phase = source["phase"];
+	        this.current_streak = source["current_streak"];
+	        this.longest_streak = source["longest_streak"];
+	        this.last_active_day = source["last_active_day"];
+	        this.active_days_7 = source["active_days_7"];
+	        this.active_days_30 = source["active_days_30"];
+	        this.active_ratio_7 = source["active_ratio_7"];
+	        this.active_ratio_30 = source["active_ratio_30"];
+	        this.reviews_today = source["reviews_today"];
+	        this.modules = this.convertValues(source["modules"], ModuleRecency);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DriftSettings {
+	    window_days: number;
+	    sensitivity: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DriftSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.window_days = source["window_days"];
+	        this.sensitivity = source["sensitivity"];
+	    }
+	}
+	export class DriftPoint {
+	    date: string;
+	    day: number;
+	    sessions: number;
+	    duration: number;
+	    score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DriftPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.day = source["day"];
+	        this.sessions = source["sessions"];
+	        this.duration = source["duration"];
+	        this.score = source["score"];
+	    }
+	}
+	export class ModuleDrift {
+	    module: string;
+	    sessions: number;
+	    days: number;
+	    points: DriftPoint[];
+	    duration_mean: number;
+	    duration_slope: number;
+	    duration_intercept: number;
+	    duration_drift: boolean;
+	    scored_sessions: number;
+	    scored_days: number;
+	    score_slope: number;
+	    score_intercept: number;
+	    score_drift: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModuleDrift(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.sessions = source["sessions"];
+	        this.days = source["days"];
+	        this.points = this.convertValues(source["points"], DriftPoint);
+	        this.duration_mean = source["duration_mean"];
+	        this.duration_slope = source["duration_slope"];
+	        this.duration_intercept = source["duration_intercept"];
+	        this.duration_drift = source["duration_drift"];
+	        this.scored_sessions = source["scored_sessions"];
+	        this.scored_days = source["scored_days"];
+	        this.score_slope = source["score_slope"];
+	        this.score_intercept = source["score_intercept"];
+	        this.score_drift = source["score_drift"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DriftReport {
+	    settings: DriftSettings;
+	    from: string;
+	    modules: ModuleDrift[];
+	    drifting: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DriftReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.settings = this.convertValues(source["settings"], DriftSettings);
+	        this.from = source["from"];
+	        this.modules = this.convertValues(source["modules"], ModuleDrift);
+	        this.drifting = source["drifting"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class BriefingStyles {
+	    tones: string[];
+	    languages: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BriefingStyles(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tones = source["tones"];
+	        this.languages = source["languages"];
+	    }
+	}
+	export class WeeklyReportSettings {
+	    enabled: boolean;
+	    folder: string;
+	    format: string;
+	    last_week_end: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeeklyReportSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.folder = source["folder"];
+	        this.format = source["format"];
+	        this.last_week_end = source["last_week_end"];
+	    }
+	}
+	export class ExamAverage {
+	    exam_type: string;
+	    exam: string;
+	    average: number;
+	    sessions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExamAverage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.exam_type = source["exam_type"];
+	        this.exam = source["exam"];
+	        this.average = source["average"];
+	        this.sessions = source["sessions"];
+	    }
+	}
+	export class ModuleSummary {
+	    module: string;
+	    minutes: number;
+	    sessions: number;
+	    avg_scores: ExamAverage[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ModuleSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.minutes = source["minutes"];
+	        this.sessions = source["sessions"];
+	        this.avg_scores = this.convertValues(source["avg_scores"], ExamAverage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScoreTrend {
+	    module: string;
+	    first: number;
+	    last: number;
+	    change: number;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreTrend(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.first = source["first"];
+	        this.last = source["last"];
+	        this.change = source["change"];
+	        this.count = source["count"];
+	    }
+	}
+	export class HomeworkSummary {
+	    set: number;
+	    completed: number;
+	    rate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HomeworkSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.set = source["set"];
+	        this.completed = source["completed"];
+	        this.rate = source["rate"];
+	    }
+	}
+	export class Report {
+	    title: string;
+	    from: string;
+	    to: string;
+	    generated_at: string;
+	    days: number;
+	    active_days: number;
+	    sessions: number;
+	    total_minutes: number;
+	    current_streak: number;
+	    longest_streak: number;
+	    modules: ModuleSummary[];
+	    scores: ScoreTrend[];
+	    vocabulary_added: string[];
+	    homework: HomeworkSummary;
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.generated_at = source["generated_at"];
+	        this.days = source["days"];
+	        this.active_days = source["active_days"];
+	        this.sessions = source["sessions"];
+	        this.total_minutes = source["total_minutes"];
+	        this.current_streak = source["current_streak"];
+	        this.longest_streak = source["longest_streak"];
+	        this.modules = this.convertValues(source["modules"], ModuleSummary);
+	        this.scores = this.convertValues(source["scores"], ScoreTrend);
+	        this.vocabulary_added = source["vocabulary_added"];
+	        this.homework = this.convertValues(source["homework"], HomeworkSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class VocabFieldMapping {
+	    word: number;
+	    def: number;
+	    sentences: number;
+	    topic: number;
+	    tags: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabFieldMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = source["word"];
+	        this.def = source["def"];
+	        this.sentences = source["sentences"];
+	        this.topic = source["topic"];
+	        this.tags = source["tags"];
+	    }
+	}
+	export class VocabImportResult {
+	    imported: number;
+	    duplicates: string[];
+	    skipped: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.imported = source["imported"];
+	        this.duplicates = source["duplicates"];
+	        this.skipped = source["skipped"];
+	    }
+	}
+	export class VocabImportPreview {
+	    path: string;
+	    columns: string[];
+	    rows: string[][];
+	    total: number;
+	    mapping: VocabFieldMapping;
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabImportPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.columns = source["columns"];
+	        this.rows = source["rows"];
+	        this.total = source["total"];
+	        this.mapping = this.convertValues(source["mapping"], VocabFieldMapping);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ReviewRecord {
+	    at: string;
+	    grade: number;
+	    interval: number;
+	    ease: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.at = source["at"];
+	        this.grade = source["grade"];
+	        this.interval = source["interval"];
+	        this.ease = source["ease"];
+	    }
+	}
+	export class ReviewState {
+	    ease: number;
+	    interval: number;
+	    due: string;
+	    reps: number;
+	    lapses: number;
+	    history: ReviewRecord[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ease = source["ease"];
+	        this.interval = source["interval"];
+	        this.due = source["due"];
+	        this.reps = source["reps"];
+	        this.lapses = source["lapses"];
+	        this.history = this.convertValues(source["history"], ReviewRecord);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DailyReviews {
+	    date: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyReviews(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.count = source["count"];
+	    }
+	}
+	export class ReviewStats {
+	    due: number;
+	    new: number;
+	    reviewed_today: number;
+	    learned: number;
+	    daily: DailyReviews[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.due = source["due"];
+	        this.new = source["new"];
+	        this.reviewed_today = source["reviewed_today"];
+	        this.learned = source["learned"];
+	        this.daily = this.convertValues(source["daily"], DailyReviews);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Sense {
+	    part_of_speech: string;
+	    definition: string;
+	    examples: string[];
+	    synonyms: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Sense(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.part_of_speech = source["part_of_speech"];
+	        this.definition = source["definition"];
+	        this.examples = source["examples"];
+	        this.synonyms = source["synonyms"];
+	    }
+	}
+	export class LookupResult {
+	    query: string;
+	    lemma: string;
+	    found: boolean;
+	    senses: Sense[];
+	    def: string;
+	    sentences: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LookupResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.lemma = source["lemma"];
+	        this.found = source["found"];
+	        this.senses = this.convertValues(source["senses"], Sense);
+	        this.def = source["def"];
+	        this.sentences = source["sentences"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class WordSuggestion {
+	    word: string;
+	    def: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordSuggestion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = source["word"];
+	        this.def = source["def"];
+	    }
+	}
+	export class ListCoverage {
+	    id: string;
+	    name: string;
+	    kind: string;
+	    total: number;
+	    covered: number;
+	    learned: number;
+	    percent: number;
+	    suggestions: WordSuggestion[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ListCoverage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.total = source["total"];
+	        this.covered = source["covered"];
+	        this.learned = source["learned"];
+	        this.percent = source["percent"];
+	        this.suggestions = this.convertValues(source["suggestions"], WordSuggestion);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TagCount {
+	    name: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.count = source["count"];
+	    }
+	}
+	export class VocabularyCoverage {
+	    awl_total: number;
+	    awl_covered: number;
+	    awl_percent: number;
+	    lists: ListCoverage[];
+	    topics: TagCount[];
+	    tags: TagCount[];
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabularyCoverage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.awl_total = source["awl_total"];
+	        this.awl_covered = source["awl_covered"];
+	        this.awl_percent = source["awl_percent"];
+	        this.lists = this.convertValues(source["lists"], ListCoverage);
+	        this.topics = this.convertValues(source["topics"], TagCount);
+	        this.tags = this.convertValues(source["tags"], TagCount);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class UsedWord {
+	    id: string;
+	    word: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UsedWord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.word = source["word"];
+	        this.count = source["count"];
+	    }
+	}
+	export class VocabCandidate {
+	    word: string;
+	    count: number;
+	    def: string;
+	    sublist: number;
+	    example: string;
+	    log_id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabCandidate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = source["word"];
+	        this.count = source["count"];
+	        this.def = source["def"];
+	        this.sublist = source["sublist"];
+	        this.example = source["example"];
+	        this.log_id = source["log_id"];
+	    }
+	}
+	export class LogVocabulary {
+	    log_id: string;
+	    words: number;
+	    used: UsedWord[];
+	    candidates: VocabCandidate[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LogVocabulary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.log_id = source["log_id"];
+	        this.words = source["words"];
+	        this.used = this.convertValues(source["used"], UsedWord);
+	        this.candidates = this.convertValues(source["candidates"], VocabCandidate);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class UsagePoint {
+	    week: string;
+	    essays: number;
+	    words: number;
+	    used: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UsagePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.week = source["week"];
+	        this.essays = source["essays"];
+	        this.words = source["words"];
+	        this.used = source["used"];
+	    }
+	}
+	export class WordUsage {
+	    id: string;
+	    word: string;
+	    count: number;
+	    last_used: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.word = source["word"];
+	        this.count = source["count"];
+	        this.last_used = source["last_used"];
+	    }
+	}
+	export class VocabularyUsage {
+	    essays: number;
+	    saved: number;
+	    used: number;
+	    percent: number;
+	    weekly: UsagePoint[];
+	    words: WordUsage[];
+	    candidates: VocabCandidate[];
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabularyUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.essays = source["essays"];
+	        this.saved = source["saved"];
+	        this.used = source["used"];
+	        this.percent = source["percent"];
+	        this.weekly = this.convertValues(source["weekly"], UsagePoint);
+	        this.words = this.convertValues(source["words"], WordUsage);
+	        this.candidates = this.convertValues(source["candidates"], VocabCandidate);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class VocabAddResult {
+	    item: VocabItem;
+	    merged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabAddResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.item = this.convertValues(source["item"], VocabItem);
+	        this.merged = source["merged"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class VocabBulkResult {
+	    deleted: number;
+	    merged: number;
+	    missing: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabBulkResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deleted = source["deleted"];
+	        this.merged = source["merged"];
+	        this.missing = source["missing"];
+	    }
+	}
+	export class VocabDuplicates {
+	    word: string;
+	    items: VocabItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabDuplicates(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = source["word"];
+	        this.items = this.convertValues(source["items"], VocabItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class VocabPatch {
+	    word?: string;
+	    def?: string;
+	    sentences?: string;
+	    topic?: string;
+	    tags?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new VocabPatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = source["word"];
+	        this.def = source["def"];
+	        this.sentences = source["sentences"];
+	        this.topic = source["topic"];
+	        this.tags = source["tags"];
+	    }
+	}
 	
 
 }
@@ -2,7 +2,10 @@ module Engress
 
 go 1.23
 
-require github.com/wailsapp/wails/v2 v2.11.0
+require (
+	github.com/wailsapp/wails/v2 v2.11.0
+	go.etcd.io/bbolt v1.4.0
+)
 
 require (
 	github.com/bep/debounce v1.2.1 // indirect
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidationError reports an input the backend refused. Wails rejects the
+// frontend promise with its message.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// LogPatch lists the fields UpdateLog may change. Nil fields are left as they are.
+type LogPatch struct {
+	Module     *string  `json:"module,omitempty"`
+	Duration   *int     `json:"duration,omitempty"`
+	Score      *float64 `json:"score,omitempty"`
+	Reflection *string  `json:"reflection,omitempty"`
+	Homework   *string  `json:"homework,omitempty"`
+	Learnings  *string  `json:"learnings,omitempty"`
+	Content    *string  `json:"content,omitempty"`
+	SourceURL  *string  `json:"source_url,omitempty"`
+	SubTask    *string  `json:"sub_task,omitempty"`
+	Scores     *Scores  `json:"scores,omitempty"`
+}
+
+// LogFilter narrows ListLogs. Empty fields match everything; From and To
+// are inclusive "2006-01-02" dates. Limit 0 means no limit.
+type LogFilter struct {
+	Module string `json:"module"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Query  string `json:"query"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+// LogPage is one page of ListLogs results, newest first.
+type LogPage struct {
+	Logs  []DailyLog `json:"logs"`
+	Total int        `json:"total"` // Matches before pagination
+}
+
+// apply patches log and validates the result. Checks whose inputs the
+// patch leaves alone are skipped, so older logs with values that no longer
+// validate (raw scores, say) can still be edited; a new module re-checks
+// the score and sub-task against it.
+func (p LogPatch) apply(log *DailyLog) error {
+	if p.Module != nil {
+		log.Module = normalizeModule(*p.Module)
+	}
+	if p.Duration != nil {
+		log.Duration = *p.Duration
+	}
+	if p.Score != nil {
+		log.Score = *p.Score
+	}
+	if p.Reflection != nil {
+		log.Reflection = *p.Reflection
+	}
+	if p.Homework != nil {
+		log.Homework = *p.Homework
+	}
+	if p.Learnings != nil {
+		log.Learnings = *p.Learnings
+	}
+	if p.Content != nil {
+		log.Content = *p.Content
+	}
+	if p.SourceURL != nil {
+		log.SourceURL = *p.SourceURL
+	}
+	if p.SubTask != nil {
+		log.SubTask = *p.SubTask
+	}
+	if p.Scores != nil {
+		log.Scores = *p.Scores
+		log.fillOverallScore()
+	}
+
+	if p.Module != nil {
+		if err := validateModule(log.Module); err != nil {
+			return err
+		}
+	}
+	if p.Duration != nil && log.Duration < 1 {
+		return &ValidationError{Field: "duration", Message: "must be at least a minute"}
+	}
+	if p.Module != nil || p.Score != nil || p.Scores != nil {
+		if err := validateScore(log.ExamType, log.Module, log.Score); err != nil {
+			return err
+		}
+	}
+	if p.Scores != nil {
+		if err := validateScores(log.ExamType, log.Scores); err != nil {
+			return err
+		}
+	}
+	if p.Module != nil || p.SubTask != nil {
+		return validateSubTask(log.Module, log.SubTask)
+	}
+	return nil
+}
+
+func (f LogFilter) matches(log DailyLog, loc *time.Location) bool {
+	day := log.day(loc)
+	if f.Module != "" && !strings.EqualFold(f.Module, log.Module) {
+		return false
+	}
+	if f.From != "" && day < f.From {
+		return false
+	}
+	if f.To != "" && day > f.To {
+		return false
+	}
+	if q := strings.ToLower(strings.TrimSpace(f.Query)); q != "" {
+		text := strings.ToLower(log.Reflection + "\n" + log.Learnings + "\n" + log.Homework + "\n" + log.Content)
+		if !strings.Contains(text, q) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetLog returns the session log with the given ID.
+func (a *App) GetLog(id string) (DailyLog, error) {
+	return a.state.GetLog(id)
+}
+
+// UpdateLog applies patch to the session log with the given ID and returns
+// the updated log.
+func (a *App) UpdateLog(id string, patch LogPatch) (DailyLog, error) {
+	var updated DailyLog
+	err := a.state.UpdateLog(id, func(log *DailyLog) error {
+		if err := patch.apply(log); err != nil {
+			return err
+		}
+		if err := a.blobs.externalizeLog(log); err != nil {
+			return err
+		}
+		updated = *log
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return DailyLog{}, fmt.Errorf("session log %s not found", id)
+	}
+	return updated, err
+}
+
+// ListLogs returns the session logs matching filter, newest first.
+func (a *App) ListLogs(filter LogFilter) (LogPage, error) {
+	if filter.Offset < 0 {
+		return LogPage{}, &ValidationError{Field: "offset", Message: "cannot be negative"}
+	}
+	if filter.Limit < 0 {
+		return LogPage{}, &ValidationError{Field: "limit", Message: "cannot be negative"}
+	}
+	state, err := a.state.LoadState()
+	if err != nil {
+		return LogPage{}, err
+	}
+
+	// By when the sessions started, not when they were saved: edits and
+	// imports can save an older session after a newer one
+	loc := state.UserProfile.location()
+	logs := sortedLogs(state.DailyLogs, loc)
+	page := LogPage{Logs: []DailyLog{}}
+	for i := len(logs) - 1; i >= 0; i-- {
+		log := logs[i]
+		if !filter.matches(log, loc) {
+			continue
+		}
+		if page.Total >= filter.Offset && (filter.Limit == 0 || len(page.Logs) < filter.Limit) {
+			page.Logs = append(page.Logs, log)
+		}
+		page.Total++
+	}
+	return page, nil
+}
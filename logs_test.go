@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"Engress/scoring"
+)
+
+// A legacy log with a raw score can still be edited, as long as the edit
+// itself is valid.
+func TestLogPatchValidatesPatchedFields(t *testing.T) {
+	legacy := DailyLog{Module: "reading", Score: 35}
+	reflection, score := "Skimmed too fast", 35.0
+
+	log := legacy
+	if err := (LogPatch{Reflection: &reflection}).apply(&log); err != nil {
+		t.Errorf("editing the reflection: %v", err)
+	}
+	if log.Reflection != reflection || log.Score != 35 {
+		t.Errorf("got %+v", log)
+	}
+
+	log = legacy
+	if err := (LogPatch{Score: &score}).apply(&log); err == nil {
+		t.Error("a raw score was accepted")
+	}
+}
+
+// Changing the module checks the score already on the log against the new
+// module's scale.
+func TestLogPatchRechecksScoreOnModuleChange(t *testing.T) {
+	log := DailyLog{Module: "mockup", Score: 120, ExamType: scoring.TOEFL}
+	reading := "reading"
+	if err := (LogPatch{Module: &reading}).apply(&log); err == nil {
+		t.Errorf("a TOEFL total of 120 was accepted as a reading score: %+v", log)
+	}
+}
+
+// Logs list by when they started, not in the order they were saved.
+func TestListLogs(t *testing.T) {
+	state := fixtureState()
+	// Saved last, but started before everything else
+	state.DailyLogs = append(state.DailyLogs, fixtureSession("listening", 20, 0, 2, 9))
+	app := fixtureApp(t, state)
+
+	page, err := app.ListLogs(LogFilter{Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, log := range page.Logs {
+		got = append(got, log.ID)
+	}
+	want := []string{state.DailyLogs[2].ID, state.DailyLogs[1].ID, state.DailyLogs[0].ID}
+	if page.Total != 4 || strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got %d logs %v, want 4 logs %v", page.Total, got, want)
+	}
+
+	for _, field := range []string{"offset", "limit"} {
+		filter := LogFilter{}
+		if field == "offset" {
+			filter.Offset = -1
+		} else {
+			filter.Limit = -1
+		}
+		var verr *ValidationError
+		if _, err := app.ListLogs(filter); !errors.As(err, &verr) || verr.Field != field {
+			t.Errorf("negative %s: got %v", field, err)
+		}
+	}
+}
@@ -1,27 +1,52 @@
 package main
 
 import (
+	"context"
 	"embed"
+	"os"
+	"path/filepath"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
 func main() {
+	// Open the data store before anything can touch it
+	dir, err := resolveDataDir(os.Args[1:])
+	if err != nil {
+		showStorageError(err)
+		return
+	}
+	store, notice, err := OpenDefaultStore(dir)
+	if err != nil {
+		showStorageError(err)
+		return
+	}
+	defer store.Close()
+
+	blobs, err := NewBlobStore(filepath.Join(dir, "blobs"))
+	if err != nil {
+		showStorageError(err)
+		return
+	}
+
 	// Create an instance of the app structure
-	app := NewApp()
+	app := NewApp(store, blobs, NewBriefingEngine(filepath.Join(dir, "briefings")), NewDictionary(filepath.Join(dir, "dictionary")))
+	app.recoveryNotice = notice
 
 	// Create application with options
-	err := wails.Run(&options.App{
+	err = wails.Run(&options.App{
 		Title:  "Engress",
 		Width:  1024,
 		Height: 768,
 		AssetServer: &assetserver.Options{
-			Assets: assets,
+			Assets:  assets,
+			Handler: blobs,
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.startup,
@@ -35,3 +60,22 @@ func main() {
 		println("Error:", err.Error())
 	}
 }
+
+// showStorageError tells the user their data could not be opened. Wails
+// dialogs need a running app, so a hidden one is started just to show it.
+func showStorageError(err error) {
+	println("Error:", err.Error())
+	wails.Run(&options.App{
+		Title:       "Engress",
+		StartHidden: true,
+		AssetServer: &assetserver.Options{Assets: assets},
+		OnStartup: func(ctx context.Context) {
+			runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
+				Type:    runtime.ErrorDialog,
+				Title:   "ENGRESS: Storage Error",
+				Message: "Could not open your data: " + err.Error(),
+			})
+			runtime.Quit(ctx)
+		},
+	})
+}
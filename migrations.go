@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSchemaTooNew means the data was written by a newer build of Engress.
+var ErrSchemaTooNew = errors.New("data was written by a newer version of Engress")
+
+// migrations upgrade persisted state one schema version at a time:
+// migrations[i] takes a state at version i to version i+1. Append new
+// steps to the end; never reorder or edit ones that have shipped.
+var migrations = []func(*AppState) error{
+	migrateAssignIDs,
+	migrateSessionTimestamps,
+}
+
+// currentSchemaVersion is the version written by this build.
+var currentSchemaVersion = len(migrations)
+
+// migrateState runs every migration newer than state.SchemaVersion and
+// reports whether anything ran.
+func migrateState(state *AppState) (bool, error) {
+	if state.SchemaVersion > currentSchemaVersion {
+		return false, fmt.Errorf("%w (schema %d, this build supports %d)", ErrSchemaTooNew, state.SchemaVersion, currentSchemaVersion)
+	}
+	if state.SchemaVersion == currentSchemaVersion {
+		return false, nil
+	}
+	for v := state.SchemaVersion; v < currentSchemaVersion; v++ {
+		if err := migrations[v](state); err != nil {
+			return false, fmt.Errorf("migrate schema %d to %d: %w", v, v+1, err)
+		}
+		state.SchemaVersion = v + 1
+	}
+	return true, nil
+}
+
+// v0 -> v1: data.json had no version. Records saved by very old builds can
+// lack an ID or share one, but the store keys records by ID, and slices may be null.
+func migrateAssignIDs(state *AppState) error {
+	seq := time.Now().UnixNano()
+	nextID := func() string {
+		seq++
+		return fmt.Sprintf("%d", seq)
+	}
+	if state.DailyLogs == nil {
+		state.DailyLogs = []DailyLog{}
+	}
+	if state.Vocabulary == nil {
+		state.Vocabulary = []VocabItem{}
+	}
+	seen := map[string]bool{}
+	for i := range state.DailyLogs {
+		if id := state.DailyLogs[i].ID; id == "" || seen[id] {
+			state.DailyLogs[i].ID = nextID()
+		}
+		seen[state.DailyLogs[i].ID] = true
+	}
+	seen = map[string]bool{}
+	for i := range state.Vocabulary {
+		if id := state.Vocabulary[i].ID; id == "" || seen[id] {
+			state.Vocabulary[i].ID = nextID()
+		}
+		seen[state.Vocabulary[i].ID] = true
+	}
+	return nil
+}
+
+// v1 -> v2: logs only had a local Date and an end Time ("15:04"). Record the
+// timezone they were taken in and backfill StartedAt/EndedAt from them. The
+// stored Date is kept: a session that would have started the day before is
+// taken to have started at midnight, so streaks and daily totals don't move.
+func migrateSessionTimestamps(state *AppState) error {
+	if state.UserProfile.Timezone == "" {
+		state.UserProfile.Timezone = systemTimezone()
+	}
+	loc := state.UserProfile.location()
+	for i := range state.DailyLogs {
+		log := &state.DailyLogs[i]
+		if log.StartedAt != "" {
+			continue
+		}
+		clock := log.Time
+		if clock == "" {
+			clock = "12:00"
+		}
+		ended, err := time.ParseInLocation(dayLayout+" 15:04", log.Date+" "+clock, loc)
+		if err != nil {
+			continue // No usable date; day bucketing falls back to Date
+		}
+		midnight, _ := time.ParseInLocation(dayLayout, log.Date, loc)
+		started := ended.Add(-time.Duration(log.Duration) * time.Minute)
+		if started.Before(midnight) {
+			started = midnight
+		}
+		log.StartedAt = started.Format(time.RFC3339)
+		log.EndedAt = ended.Format(time.RFC3339)
+		log.Time = ended.Format("15:04")
+	}
+	return nil
+}
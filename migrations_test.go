@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMigrateSessionTimestamps(t *testing.T) {
+	state := &AppState{
+		SchemaVersion: 1,
+		UserProfile:   UserProfile{Timezone: "Asia/Jakarta"},
+		DailyLogs: []DailyLog{
+			// Ended half an hour after midnight, an hour long
+			{ID: "1", Date: "2026-03-02", Time: "00:30", Module: "writing", Duration: 60},
+			{ID: "2", Date: "2026-03-01", Time: "18:00", Module: "reading", Duration: 45},
+			{ID: "3", Date: "2026-03-01", Module: "speaking", Duration: 20},
+			// Already stamped, left alone
+			{ID: "4", Date: "2026-03-01", Time: "23:10", Module: "listening", Duration: 30,
+				StartedAt: "2026-03-01T22:40:00+07:00", EndedAt: "2026-03-01T23:10:00+07:00"},
+		},
+	}
+	if _, err := migrateState(state); err != nil {
+		t.Fatal(err)
+	}
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	want := []struct{ date, started, ended string }{
+		{"2026-03-02", "2026-03-02T00:00:00+07:00", "2026-03-02T00:30:00+07:00"},
+		{"2026-03-01", "2026-03-01T17:15:00+07:00", "2026-03-01T18:00:00+07:00"},
+		{"2026-03-01", "2026-03-01T11:40:00+07:00", "2026-03-01T12:00:00+07:00"},
+		{"2026-03-01", "2026-03-01T22:40:00+07:00", "2026-03-01T23:10:00+07:00"},
+	}
+	for i, w := range want {
+		log := state.DailyLogs[i]
+		if log.Date != w.date || log.day(loc) != w.date || log.StartedAt != w.started || log.EndedAt != w.ended {
+			t.Errorf("log %s: got %s (day %s) %s to %s, want %s %s to %s", log.ID, log.Date, log.day(loc), log.StartedAt, log.EndedAt, w.date, w.started, w.ended)
+		}
+	}
+	if state.DailyLogs[0].Duration != 60 {
+		t.Errorf("duration changed to %d", state.DailyLogs[0].Duration)
+	}
+}
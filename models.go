@@ -9,6 +9,17 @@ type UserProfile struct {
 	ReminderTimes   []string `json:"reminder_times"`    // ["10:00", "22:00"]
 	ReminderEnabled bool     `json:"reminder_enabled"`
 	TutorialSeen    bool     `json:"tutorial_seen"`
+	BackupsToKeep   int      `json:"backups_to_keep"` // 0 means defaultBackupsToKeep
+	Timezone        string   `json:"timezone"`        // IANA name, e.g. "Asia/Jakarta"; empty follows the system
+	ExamType        string   `json:"exam_type"`       // See scoring package; empty means IELTS Academic
+
+	StudyTargets StudyTargets  `json:"study_targets"` // See targets.go
+	Drift        DriftSettings `json:"drift"`         // See drift.go
+
+	BriefingTone     string `json:"briefing_tone"`     // See briefing.go; empty means drill sergeant
+	BriefingLanguage string `json:"briefing_language"` // e.g. "en", "id"; empty means English
+
+	WeeklyReport WeeklyReportSettings `json:"weekly_report"` // See report.go
 }
 
 type Scores struct {
@@ -23,27 +34,42 @@ type DailyLog struct {
 	Date       string  `json:"date"`
 	Module     string  `json:"module"`     // "writing", "speaking", "reading"
 	Duration   int     `json:"duration"`   // in minutes
-	Score      float64 `json:"score"`      // Raw score or Band
+	Score      float64 `json:"score"`      // On the exam's scale; the overall score for a full mock test
 	Reflection string  `json:"reflection"` // User notes (Obstacles)
 	Homework   string  `json:"homework"`   // "Tomorrow's focus"
 	Learnings  string  `json:"learnings"`  // Key points/Notes
 	Content    string  `json:"content"`    // Actual work (essays, notes, etc.)
 	SourceURL  string  `json:"source_url"` // Original question URL
-	Screenshot string  `json:"screenshot"` // Base64 encoded image
+	Screenshot string  `json:"screenshot"` // Blob URL (legacy logs: base64 data URL)
 	Time       string  `json:"time"`       // "15:04"
+
+	Attachments []string `json:"attachments"` // Blob IDs referenced by Screenshot and Content
+
+	Scores Scores `json:"scores"` // Per-section scores of a mock test; zero if not recorded
+
+	ExamType  string `json:"exam_type"`  // See scoring package; empty means IELTS Academic
+	SubTask   string `json:"sub_task"`   // "task1", "part2", ...
+	StartedAt string `json:"started_at"` // RFC3339, empty for older logs
+	EndedAt   string `json:"ended_at"`   // RFC3339, empty for older logs
 }
 
 type VocabItem struct {
-	ID        string `json:"id"`
-	Word      string `json:"word"`
-	Def       string `json:"def"`
-	Sentences string `json:"sentences"` // Stored as newline separated
-	DateAdded string `json:"date_added"`
-	Time      string `json:"time"`
+	ID        string   `json:"id"`
+	Word      string   `json:"word"`
+	Def       string   `json:"def"`
+	Sentences string   `json:"sentences"` // Stored as newline separated
+	DateAdded string   `json:"date_added"`
+	Time      string   `json:"time"`
+	Topic     string   `json:"topic"` // Lowercase, e.g. "environment"; empty if none
+	Tags      []string `json:"tags"`  // Lowercase, without duplicates
+
+	Review ReviewState `json:"review"` // Spaced repetition; zero for a word never reviewed
 }
 
 type AppState struct {
-	UserProfile UserProfile `json:"user_profile"`
-	DailyLogs   []DailyLog  `json:"daily_logs"`
-	Vocabulary  []VocabItem `json:"vocabulary"`
+	SchemaVersion int         `json:"schema_version"` // See migrations.go
+	UserProfile   UserProfile `json:"user_profile"`
+	DailyLogs     []DailyLog  `json:"daily_logs"`
+	Vocabulary    []VocabItem `json:"vocabulary"`
+	StudyPlan     *StudyPlan  `json:"study_plan,omitempty"` // See plan.go
 }
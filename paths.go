@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const dataDirEnv = "ENGRESS_DATA_DIR"
+
+// dataFiles are moved from the legacy macOS-style location on first run.
+var dataFiles = []string{"engress.db", "data.json"}
+
+// resolveDataDir picks the data directory, in order of preference: the
+// --data-dir flag, $ENGRESS_DATA_DIR, then the platform default. Data left
+// in the old hardcoded ~/Library/Application Support/Engress path is moved
+// into the default directory when that is somewhere else.
+func resolveDataDir(args []string) (string, error) {
+	dir := dataDirFlag(args)
+	if dir == "" {
+		dir = os.Getenv(dataDirEnv)
+	}
+	explicit := dir != ""
+	if !explicit {
+		var err error
+		if dir, err = platformDataDir(); err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create data directory: %w", err)
+	}
+	if !explicit {
+		if err := relocateLegacyData(dir); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// dataDirFlag scans args by hand because Wails passes its own flags in dev mode.
+func dataDirFlag(args []string) string {
+	for i, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--data-dir="):
+			return strings.TrimPrefix(arg, "--data-dir=")
+		case arg == "--data-dir" && i+1 < len(args):
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func platformDataDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locate home directory: %w", err)
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return legacyDataDir(home), nil
+	case "windows":
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "Engress"), nil
+		}
+		return filepath.Join(home, "AppData", "Roaming", "Engress"), nil
+	default:
+		if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+			return filepath.Join(xdg, "engress"), nil
+		}
+		return filepath.Join(home, ".local", "share", "engress"), nil
+	}
+}
+
+func legacyDataDir(home string) string {
+	return filepath.Join(home, "Library", "Application Support", "Engress")
+}
+
+// relocateLegacyData moves data files from the legacy path into dir unless
+// dir already has its own copy.
+func relocateLegacyData(dir string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	legacy := legacyDataDir(home)
+	if filepath.Clean(legacy) == filepath.Clean(dir) {
+		return nil
+	}
+	for _, name := range dataFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return nil
+		}
+	}
+	for _, name := range dataFiles {
+		src := filepath.Join(legacy, name)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		if err := moveFile(src, filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("relocate %s: %w", src, err)
+		}
+	}
+	return nil
+}
+
+// moveFile renames src to dst, copying when they are on different volumes.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
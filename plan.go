@@ -0,0 +1,345 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"Engress/scoring"
+)
+
+const (
+	planBlockMinutes = 30  // Sessions are planned in blocks of this length
+	maxPlanDays      = 120 // Plans never reach further ahead than this
+	// planTolerance is how far a day's logged minutes for a skill may stray
+	// from the plan before the rest of the plan is rebalanced.
+	planTolerance = 15
+)
+
+// PlannedSession is one module to practise on a plan day.
+type PlannedSession struct {
+	Module  string `json:"module"`
+	Minutes int    `json:"minutes"`
+	Logged  int    `json:"logged"` // Minutes actually logged for the module that day; filled in on read
+}
+
+// PlanDay is one day of a StudyPlan.
+type PlanDay struct {
+	Date     string           `json:"date"`
+	Mock     bool             `json:"mock"` // A full mock test day
+	Sessions []PlannedSession `json:"sessions"`
+}
+
+// StudyPlan is a day-by-day schedule from the day it was generated up to
+// the test date.
+type StudyPlan struct {
+	GeneratedAt  string `json:"generated_at"`  // RFC3339
+	RebalancedAt string `json:"rebalanced_at"` // RFC3339, empty until the first rebalance
+	// CheckedThrough is the first day not yet compared against the logs.
+	CheckedThrough string `json:"checked_through"`
+
+	// The profile settings the plan was built for; a change triggers a rebalance.
+	ExamType    string  `json:"exam_type"`
+	TestDate    string  `json:"test_date"`
+	TargetScore float64 `json:"target_score"`
+
+	Weights map[string]float64 `json:"weights"` // Share of non-mock time per skill
+	Days    []PlanDay          `json:"days"`
+}
+
+func (p *StudyPlan) clone() *StudyPlan {
+	if p == nil {
+		return nil
+	}
+	out := *p
+	out.Weights = make(map[string]float64, len(p.Weights))
+	for skill, w := range p.Weights {
+		out.Weights[skill] = w
+	}
+	out.Days = make([]PlanDay, len(p.Days))
+	for i, day := range p.Days {
+		day.Sessions = append([]PlannedSession(nil), day.Sessions...)
+		out.Days[i] = day
+	}
+	return &out
+}
+
+// isMockDay spaces full mock tests closer together as the test approaches,
+// leaving the last two days free.
+func isMockDay(daysLeft int) bool {
+	switch {
+	case daysLeft <= 2:
+		return false
+	case daysLeft <= 14:
+		return daysLeft%3 == 0
+	case daysLeft <= 42:
+		return daysLeft%7 == 0
+	default:
+		return daysLeft%14 == 0
+	}
+}
+
+// planWeights gives each skill a share of study time that grows with its
+// gap to the target. Skills with no score yet get a moderate share.
+func planWeights(history ScoreHistory, profile UserProfile) map[string]float64 {
+	exam := scoring.MustLookup(profile.ExamType)
+	target := profile.TargetScore
+	if exam.Total {
+		target /= float64(len(exam.Sections))
+	}
+
+	weights := make(map[string]float64, len(skills))
+	sum := 0.0
+	for _, skill := range skills {
+		w := 1.5
+		if recent := history.Recent.get(skill); recent > 0 {
+			scale := exam.ScaleFor(skill)
+			// A gap of a tenth of the scale (about one IELTS band) doubles the share
+			gap := (target - recent) / (scale.Max - scale.Min) * 10
+			w = 1 + math.Max(0, gap)
+		}
+		weights[skill] = w
+		sum += w
+	}
+	for skill := range weights {
+		weights[skill] /= sum
+	}
+	return weights
+}
+
+// generatePlan schedules every day from now until the day before the test.
+// Minutes already logged per skill since since ("2006-01-02") count towards
+// each skill's share, so a plan built over past deviations makes up for
+// them. An empty since starts from a clean slate today.
+func generatePlan(logs []DailyLog, profile UserProfile, now time.Time, since string) (*StudyPlan, error) {
+	loc := profile.location()
+	now = now.In(loc)
+	daysLeft, ok := profile.daysUntilTest(now)
+	if !ok || daysLeft <= 0 {
+		return nil, &ValidationError{Field: "test_date", Message: "must be a future date to plan towards"}
+	}
+	today := now.Format(dayLayout)
+	if since == "" {
+		since = today
+	}
+
+	plan := &StudyPlan{
+		GeneratedAt:    now.Format(time.RFC3339),
+		CheckedThrough: today,
+		ExamType:       scoring.MustLookup(profile.ExamType).ID,
+		TestDate:       profile.TestDate,
+		TargetScore:    profile.TargetScore,
+		Weights:        planWeights(buildScoreHistory(logs, profile), profile),
+		Days:           []PlanDay{},
+	}
+
+	allocated := make(map[string]int, len(skills))
+	for _, log := range logs {
+		if day := log.day(loc); day >= since && day < today {
+			allocated[strings.ToLower(log.Module)] += log.Duration
+		}
+	}
+	// nextSkill picks the skill furthest behind its weighted share
+	nextSkill := func() string {
+		best, bestLoad := "", math.Inf(1)
+		for _, skill := range skills {
+			load := float64(allocated[skill]+planBlockMinutes) / plan.Weights[skill]
+			if load < bestLoad {
+				best, bestLoad = skill, load
+			}
+		}
+		return best
+	}
+
+	daily := profile.StudyTargets.DailyMinutes
+	if daily <= 0 {
+		daily = defaultDailyMinutes
+	}
+	horizon := daysLeft
+	if horizon > maxPlanDays {
+		horizon = maxPlanDays
+	}
+	for i := 0; i < horizon; i++ {
+		left := daysLeft - i
+		factor := 1.0
+		if profile.StudyTargets.Taper {
+			factor = taperFactor(left)
+		}
+		minutes := scaleMinutes(daily, factor)
+		day := PlanDay{Date: now.AddDate(0, 0, i).Format(dayLayout), Sessions: []PlannedSession{}}
+
+		if isMockDay(left) {
+			day.Mock = true
+			day.Sessions = append(day.Sessions, PlannedSession{Module: "mockup", Minutes: minutes})
+			plan.Days = append(plan.Days, day)
+			continue
+		}
+
+		blocks := int(math.Max(1, math.Round(float64(minutes)/planBlockMinutes)))
+		for b := 0; b < blocks; b++ {
+			length := planBlockMinutes
+			if b == blocks-1 {
+				length = minutes - planBlockMinutes*(blocks-1)
+			}
+			if length <= 0 {
+				break
+			}
+			skill := nextSkill()
+			allocated[skill] += length
+			day.addMinutes(skill, length)
+		}
+		plan.Days = append(plan.Days, day)
+	}
+	return plan, nil
+}
+
+// addMinutes adds to the day's session for module, creating it if needed.
+func (d *PlanDay) addMinutes(module string, minutes int) {
+	for i := range d.Sessions {
+		if d.Sessions[i].Module == module {
+			d.Sessions[i].Minutes += minutes
+			return
+		}
+	}
+	d.Sessions = append(d.Sessions, PlannedSession{Module: module, Minutes: minutes})
+}
+
+// loggedByDay sums logged minutes per day and lowercased module.
+func loggedByDay(logs []DailyLog, loc *time.Location) map[string]map[string]int {
+	out := make(map[string]map[string]int)
+	for _, log := range logs {
+		day := log.day(loc)
+		if out[day] == nil {
+			out[day] = make(map[string]int)
+		}
+		out[day][strings.ToLower(log.Module)] += log.Duration
+	}
+	return out
+}
+
+// deviates reports whether any skill's logged minutes on a day between
+// CheckedThrough and today strayed from the plan by more than planTolerance.
+func (p *StudyPlan) deviates(logged map[string]map[string]int, today string) bool {
+	for _, day := range p.Days {
+		if day.Date < p.CheckedThrough || day.Date >= today || day.Mock {
+			continue
+		}
+		planned := make(map[string]int, len(day.Sessions))
+		for _, s := range day.Sessions {
+			planned[s.Module] = s.Minutes
+		}
+		for _, skill := range skills {
+			diff := logged[day.Date][skill] - planned[skill]
+			if diff > planTolerance || diff < -planTolerance {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rebalancePlan re-plans the days from today on when the logs have strayed
+// from the plan or the profile's test settings changed. Past days are kept
+// as they were planned. It returns false if the plan needed no change.
+func rebalancePlan(plan *StudyPlan, logs []DailyLog, profile UserProfile, now time.Time) (*StudyPlan, bool, error) {
+	loc := profile.location()
+	today := now.In(loc).Format(dayLayout)
+
+	changed := plan.ExamType != scoring.MustLookup(profile.ExamType).ID ||
+		plan.TestDate != profile.TestDate || plan.TargetScore != profile.TargetScore
+	if !changed && !plan.deviates(loggedByDay(logs, loc), today) {
+		if plan.CheckedThrough < today {
+			plan = plan.clone()
+			plan.CheckedThrough = today
+			return plan, true, nil
+		}
+		return plan, false, nil
+	}
+
+	since := today
+	if len(plan.Days) > 0 && plan.Days[0].Date < today {
+		since = plan.Days[0].Date
+	}
+	next, err := generatePlan(logs, profile, now, since)
+	if err != nil {
+		return nil, false, err
+	}
+	past := []PlanDay{}
+	for _, day := range plan.Days {
+		if day.Date < today {
+			past = append(past, day)
+		}
+	}
+	next.Days = append(past, next.Days...)
+	next.GeneratedAt = plan.GeneratedAt
+	next.RebalancedAt = now.In(loc).Format(time.RFC3339)
+	return next, true, nil
+}
+
+// withLogged returns a copy of plan with Logged filled in for each session.
+func (p *StudyPlan) withLogged(logs []DailyLog, loc *time.Location) StudyPlan {
+	out := p.clone()
+	logged := loggedByDay(logs, loc)
+	for i := range out.Days {
+		day := &out.Days[i]
+		for j := range day.Sessions {
+			day.Sessions[j].Logged = logged[day.Date][day.Sessions[j].Module]
+		}
+	}
+	return *out
+}
+
+// GetStudyPlan returns the current study plan, generating one if there is
+// none and rebalancing it if the logged sessions have strayed from it.
+func (a *App) GetStudyPlan() (StudyPlan, error) {
+	var out StudyPlan
+	err := a.state.Do(func(store Store) error {
+		state, err := store.LoadState()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		plan, changed := state.StudyPlan, false
+		if plan == nil {
+			plan, err = generatePlan(state.DailyLogs, state.UserProfile, now, "")
+			changed = true
+		} else {
+			plan, changed, err = rebalancePlan(plan, state.DailyLogs, state.UserProfile, now)
+		}
+		if err != nil {
+			return err
+		}
+		out = plan.withLogged(state.DailyLogs, state.UserProfile.location())
+		// Publishing only on change keeps state-changed listeners that
+		// fetch the plan from looping
+		if !changed {
+			return errNoChange
+		}
+		return store.SavePlan(plan)
+	})
+	if err != nil {
+		return StudyPlan{}, err
+	}
+	return out, nil
+}
+
+// RegeneratePlan discards the current study plan and builds a fresh one.
+func (a *App) RegeneratePlan() (StudyPlan, error) {
+	var out StudyPlan
+	err := a.state.Do(func(store Store) error {
+		state, err := store.LoadState()
+		if err != nil {
+			return err
+		}
+		plan, err := generatePlan(state.DailyLogs, state.UserProfile, time.Now(), "")
+		if err != nil {
+			return err
+		}
+		out = plan.withLogged(state.DailyLogs, state.UserProfile.location())
+		return store.SavePlan(plan)
+	})
+	if err != nil {
+		return StudyPlan{}, err
+	}
+	return out, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// GetStudyPlan saves and publishes a plan it generates, but not one it
+// only reads back.
+func TestGetStudyPlanPublishesChanges(t *testing.T) {
+	// GetStudyPlan plans from the wall clock, so the test date must be ahead of it
+	state := fixtureState()
+	state.UserProfile.TestDate = time.Now().AddDate(0, 0, 30).Format(dayLayout)
+	a := fixtureApp(t, state)
+	published := 0
+	a.state.SetEmitter(func(event string, data interface{}) { published++ })
+
+	first, err := a.GetStudyPlan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := a.GetStudyPlan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published != 1 {
+		t.Errorf("published %d times, want 1", published)
+	}
+	if first.GeneratedAt != second.GeneratedAt || len(first.Days) != len(second.Days) {
+		t.Error("the saved plan was not returned the second time")
+	}
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"Engress/scoring"
+)
+
+// Report formats, also used as file extensions.
+const (
+	ReportMarkdown = "md"
+	ReportHTML     = "html"
+	ReportPDF      = "pdf"
+)
+
+// weeklyReportHour is when on Sunday the week counts as done and its
+// automatic report is written.
+const weeklyReportHour = 21
+
+// weeklyReportRetry is how long the scheduler waits before trying a
+// failed weekly report again.
+const weeklyReportRetry = time.Hour
+
+// WeeklyReportSettings controls the automatic Sunday report.
+type WeeklyReportSettings struct {
+	Enabled bool   `json:"enabled"`
+	Folder  string `json:"folder"`
+	Format  string `json:"format"` // "md", "html" or "pdf"
+	// LastWeekEnd is the Sunday ("2006-01-02") of the last week written.
+	LastWeekEnd string `json:"last_week_end"`
+}
+
+// ExamAverage is the mean score of a module's scored sessions of one exam
+// type, whose scales differ too much to average together.
+type ExamAverage struct {
+	ExamType string  `json:"exam_type"`
+	Exam     string  `json:"exam"`    // Display name
+	Average  float64 `json:"average"` // Rounded to one decimal
+	Sessions int     `json:"sessions"`
+}
+
+// ModuleSummary is one module's share of a report's range.
+type ModuleSummary struct {
+	Module    string        `json:"module"`
+	Minutes   int           `json:"minutes"`
+	Sessions  int           `json:"sessions"`
+	AvgScores []ExamAverage `json:"avg_scores"` // By exam type, first logged first; empty if no session was scored
+}
+
+// ScoreTrend is how a skill's scores moved across a report's range.
+type ScoreTrend struct {
+	Module string  `json:"module"`
+	First  float64 `json:"first"`
+	Last   float64 `json:"last"`
+	Change float64 `json:"change"`
+	Count  int     `json:"count"`
+}
+
+// HomeworkSummary counts the "tomorrow's focus" notes set in a report's
+// range. A note counts as completed when a session was logged the next day.
+type HomeworkSummary struct {
+	Set       int     `json:"set"`
+	Completed int     `json:"completed"`
+	Rate      float64 `json:"rate"` // Completed / Set, 0 when none were set
+}
+
+// Report summarizes the sessions and vocabulary of an inclusive date range.
+type Report struct {
+	Title       string `json:"title"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	GeneratedAt string `json:"generated_at"` // RFC3339
+
+	Days          int `json:"days"`
+	ActiveDays    int `json:"active_days"`
+	Sessions      int `json:"sessions"`
+	TotalMinutes  int `json:"total_minutes"`
+	CurrentStreak int `json:"current_streak"` // As of To
+	LongestStreak int `json:"longest_streak"` // Within the range
+
+	Modules         []ModuleSummary `json:"modules"`
+	Scores          []ScoreTrend    `json:"scores"`
+	VocabularyAdded []string        `json:"vocabulary_added"`
+	Homework        HomeworkSummary `json:"homework"`
+}
+
+// reportRange returns the dates of the current "week" (Monday to today) or
+// "month" (the 1st to today).
+func reportRange(period string, now time.Time) (string, string, error) {
+	to := now.Format(dayLayout)
+	switch period {
+	case "week":
+		weekday := (int(now.Weekday()) + 6) % 7
+		return now.AddDate(0, 0, -weekday).Format(dayLayout), to, nil
+	case "month":
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format(dayLayout), to, nil
+	}
+	return "", "", &ValidationError{Field: "period", Message: fmt.Sprintf("unknown period %q", period)}
+}
+
+func buildReport(state *AppState, from, to string, now time.Time) (Report, error) {
+	profile := state.UserProfile
+	loc := profile.location()
+	start, err := time.ParseInLocation(dayLayout, from, loc)
+	if err != nil {
+		return Report{}, &ValidationError{Field: "from", Message: "must be a 2006-01-02 date"}
+	}
+	end, err := time.ParseInLocation(dayLayout, to, loc)
+	if err != nil {
+		return Report{}, &ValidationError{Field: "to", Message: "must be a 2006-01-02 date"}
+	}
+	if end.Before(start) {
+		return Report{}, &ValidationError{Field: "to", Message: "is before from"}
+	}
+
+	r := Report{
+		Title:           fmt.Sprintf("Engress progress report, %s to %s", from, to),
+		From:            from,
+		To:              to,
+		GeneratedAt:     now.In(loc).Format(time.RFC3339),
+		Days:            int(end.Sub(start).Hours()/24+0.5) + 1,
+		Modules:         []ModuleSummary{},
+		Scores:          []ScoreTrend{},
+		VocabularyAdded: []string{},
+	}
+
+	activeDays := make(map[string]bool)
+	modules := make(map[string]*ModuleSummary)
+	sums := make(map[string][]float64) // Score sums by module, parallel to AvgScores
+	var inRange, upToEnd []DailyLog
+	for _, log := range state.DailyLogs {
+		day := log.day(loc)
+		if day > to {
+			continue
+		}
+		upToEnd = append(upToEnd, log)
+		if day < from {
+			continue
+		}
+		inRange = append(inRange, log)
+		activeDays[day] = true
+		r.Sessions++
+		r.TotalMinutes += log.Duration
+
+		module := strings.ToLower(log.Module)
+		m := modules[module]
+		if m == nil {
+			m = &ModuleSummary{Module: module, AvgScores: []ExamAverage{}}
+			modules[module] = m
+		}
+		m.Minutes += log.Duration
+		m.Sessions++
+		if log.Score > 0 {
+			exam := scoring.MustLookup(log.ExamType)
+			i := 0
+			for i < len(m.AvgScores) && m.AvgScores[i].ExamType != exam.ID {
+				i++
+			}
+			if i == len(m.AvgScores) {
+				m.AvgScores = append(m.AvgScores, ExamAverage{ExamType: exam.ID, Exam: exam.Name})
+				sums[module] = append(sums[module], 0)
+			}
+			m.AvgScores[i].Sessions++
+			sums[module][i] += log.Score
+		}
+	}
+	r.ActiveDays = len(activeDays)
+	for module, m := range modules {
+		for i := range m.AvgScores {
+			m.AvgScores[i].Average = math.Round(sums[module][i]/float64(m.AvgScores[i].Sessions)*10) / 10
+		}
+		r.Modules = append(r.Modules, *m)
+	}
+	sort.Slice(r.Modules, func(i, j int) bool {
+		return r.Modules[i].Minutes > r.Modules[j].Minutes
+	})
+
+	history := buildScoreHistory(state.DailyLogs, profile)
+	for _, skill := range skills {
+		t := ScoreTrend{Module: skill}
+		for _, p := range *history.series(skill) {
+			if p.Date < from || p.Date > to {
+				continue
+			}
+			if t.Count == 0 {
+				t.First = p.Score
+			}
+			t.Last = p.Score
+			t.Count++
+		}
+		if t.Count > 0 {
+			t.Change = t.Last - t.First
+			r.Scores = append(r.Scores, t)
+		}
+	}
+
+	reviewsUpToEnd, reviewsInRange := make(map[string]int), make(map[string]int)
+	for day, n := range reviewCounts(state.Vocabulary, loc) {
+		if day <= to {
+			reviewsUpToEnd[day] = n
+		}
+		if day >= from && day <= to {
+			reviewsInRange[day] = n
+		}
+	}
+	endOfRange := end.Add(12 * time.Hour)
+	r.CurrentStreak = consistencyReport(upToEnd, reviewsUpToEnd, loc, endOfRange).CurrentStreak
+	r.LongestStreak = consistencyReport(inRange, reviewsInRange, loc, endOfRange).LongestStreak
+
+	for _, item := range state.Vocabulary {
+		if item.DateAdded >= from && item.DateAdded <= to {
+			r.VocabularyAdded = append(r.VocabularyAdded, item.Word)
+		}
+	}
+
+	allDays := make(map[string]bool)
+	for _, log := range state.DailyLogs {
+		allDays[log.day(loc)] = true
+	}
+	for _, log := range inRange {
+		if strings.TrimSpace(log.Homework) == "" {
+			continue
+		}
+		r.Homework.Set++
+		next := log.sessionStart(loc).AddDate(0, 0, 1).Format(dayLayout)
+		if allDays[next] {
+			r.Homework.Completed++
+		}
+	}
+	if r.Homework.Set > 0 {
+		r.Homework.Rate = float64(r.Homework.Completed) / float64(r.Homework.Set)
+	}
+	return r, nil
+}
+
+// renderReport writes the report in format ("md", "html" or "pdf") to w.
+func renderReport(w io.Writer, r Report, format string) error {
+	switch format {
+	case ReportMarkdown:
+		return markdownReport.Execute(w, r)
+	case ReportHTML:
+		return htmlReport.Execute(w, r)
+	case ReportPDF:
+		return writeReportPDF(w, r)
+	}
+	return &ValidationError{Field: "format", Message: fmt.Sprintf("unknown report format %q", format)}
+}
+
+// GetReport summarizes the inclusive range from..to ("2006-01-02" dates).
+func (a *App) GetReport(from string, to string) (Report, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return Report{}, err
+	}
+	return buildReport(state, from, to, time.Now())
+}
+
+// ExportReport renders the current "week" or "month" as a Markdown, HTML
+// or PDF file chosen in a save dialog. It returns the saved path, or ""
+// if the dialog was cancelled.
+func (a *App) ExportReport(period string, format string) (string, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().In(state.UserProfile.location())
+	from, to, err := reportRange(period, now)
+	if err != nil {
+		return "", err
+	}
+	report, err := buildReport(state, from, to, now)
+	if err != nil {
+		return "", err
+	}
+
+	filters := map[string]runtime.FileFilter{
+		ReportMarkdown: {DisplayName: "Markdown Files (*.md)", Pattern: "*.md"},
+		ReportHTML:     {DisplayName: "HTML Files (*.html)", Pattern: "*.html"},
+		ReportPDF:      {DisplayName: "PDF Files (*.pdf)", Pattern: "*.pdf"},
+	}
+	filter, ok := filters[format]
+	if !ok {
+		return "", &ValidationError{Field: "format", Message: fmt.Sprintf("unknown report format %q", format)}
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: fmt.Sprintf("engress-%s-report-%s.%s", period, to, format),
+		Title:           "Export Progress Report",
+		Filters:         []runtime.FileFilter{filter},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	err = writeFileAtomic(path, 0644, func(w io.Writer) error {
+		return renderReport(w, report, format)
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// UpdateWeeklyReport turns the automatic Sunday report on or off and sets
+// where and in which format it is written.
+func (a *App) UpdateWeeklyReport(enabled bool, folder string, format string) error {
+	if format != ReportMarkdown && format != ReportHTML && format != ReportPDF {
+		return &ValidationError{Field: "format", Message: fmt.Sprintf("unknown report format %q", format)}
+	}
+	if enabled {
+		if info, err := os.Stat(folder); err != nil || !info.IsDir() {
+			return &ValidationError{Field: "folder", Message: "must be an existing folder"}
+		}
+	}
+	return a.state.UpdateProfile(func(p *UserProfile) error {
+		p.WeeklyReport.Enabled = enabled
+		p.WeeklyReport.Folder = folder
+		p.WeeklyReport.Format = format
+		return nil
+	})
+}
+
+// ChooseReportFolder asks the user for the folder weekly reports go to.
+// It returns "" if the dialog was cancelled.
+func (a *App) ChooseReportFolder() (string, error) {
+	return runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                "Weekly Report Folder",
+		CanCreateDirectories: true,
+	})
+}
+
+// dueWeekEnd returns the Sunday of the latest week whose report is due:
+// this week's from Sunday evening, otherwise last week's.
+func dueWeekEnd(now time.Time) string {
+	daysSinceSunday := int(now.Weekday())
+	if daysSinceSunday == 0 && now.Hour() < weeklyReportHour {
+		daysSinceSunday = 7
+	}
+	return now.AddDate(0, 0, -daysSinceSunday).Format(dayLayout)
+}
+
+// writeWeeklyReport writes the automatic weekly report if one is due. It
+// is called from the scheduler, and catches up on a missed Sunday the next
+// time the app runs.
+func (a *App) writeWeeklyReport(now time.Time) error {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return err
+	}
+	settings := state.UserProfile.WeeklyReport
+	now = now.In(state.UserProfile.location())
+	weekEnd := dueWeekEnd(now)
+	if !settings.Enabled || settings.Folder == "" || settings.LastWeekEnd >= weekEnd {
+		return nil
+	}
+
+	end, _ := time.ParseInLocation(dayLayout, weekEnd, now.Location())
+	from := end.AddDate(0, 0, -6).Format(dayLayout)
+	report, err := buildReport(state, from, weekEnd, now)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(settings.Folder, fmt.Sprintf("engress-week-%s.%s", weekEnd, settings.Format))
+	if err := writeFileAtomic(path, 0644, func(w io.Writer) error {
+		return renderReport(w, report, settings.Format)
+	}); err != nil {
+		return err
+	}
+	return a.state.UpdateProfile(func(p *UserProfile) error {
+		p.WeeklyReport.LastWeekEnd = weekEnd
+		return nil
+	})
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 in points, and the layout of a report page.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 56
+	pdfMaxChars   = 90 // Wrap width of body text in Helvetica 11
+)
+
+// pdfLine is one line of report text and its font size.
+type pdfLine struct {
+	text string
+	size float64
+	bold bool
+}
+
+// reportLines lays the report out as plain lines, in the same order as the
+// Markdown and HTML versions.
+func reportLines(r Report) []pdfLine {
+	var lines []pdfLine
+	heading := func(text string) {
+		lines = append(lines, pdfLine{}, pdfLine{text: text, size: 14, bold: true})
+	}
+	body := func(format string, args ...interface{}) {
+		for _, l := range wrapText(fmt.Sprintf(format, args...), pdfMaxChars) {
+			lines = append(lines, pdfLine{text: l, size: 11})
+		}
+	}
+
+	lines = append(lines, pdfLine{text: r.Title, size: 18, bold: true})
+	body("Generated %s", r.GeneratedAt)
+
+	heading("Summary")
+	body("Sessions: %d", r.Sessions)
+	body("Minutes studied: %d", r.TotalMinutes)
+	body("Active days: %d of %d", r.ActiveDays, r.Days)
+	body("Current streak: %d days", r.CurrentStreak)
+	body("Longest streak in range: %d days", r.LongestStreak)
+
+	heading("Modules")
+	if len(r.Modules) == 0 {
+		body("No sessions logged.")
+	}
+	for _, m := range r.Modules {
+		body("%s: %d min in %d sessions, avg score %s", title(m.Module), m.Minutes, m.Sessions, formatAverages(m.AvgScores))
+	}
+
+	heading("Score Trend")
+	if len(r.Scores) == 0 {
+		body("No scores recorded.")
+	}
+	for _, s := range r.Scores {
+		body("%s: %s to %s (%s) over %d scores", title(s.Module), formatScore(s.First), formatScore(s.Last), formatChange(s.Change), s.Count)
+	}
+
+	heading("Vocabulary")
+	body("%d words added.", len(r.VocabularyAdded))
+	if len(r.VocabularyAdded) > 0 {
+		body("%s", strings.Join(r.VocabularyAdded, ", "))
+	}
+
+	heading("Homework")
+	body("%d of %d completed (%d%%).", r.Homework.Completed, r.Homework.Set, int(r.Homework.Rate*100+0.5))
+	return lines
+}
+
+// wrapText splits s into lines of at most width characters, breaking at spaces.
+func wrapText(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	return append(lines, line)
+}
+
+// pdfString encodes s as a PDF literal string in WinAnsi, which the
+// standard fonts use. Characters outside Latin-1 become '?'.
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// writeReportPDF writes the report as a plain-text PDF using the built-in
+// Helvetica fonts, so no font files or PDF library are needed.
+func writeReportPDF(w io.Writer, r Report) error {
+	// Lay the lines out into page content streams
+	var pages []string
+	var page bytes.Buffer
+	y := float64(pdfPageHeight - pdfMargin)
+	for _, l := range reportLines(r) {
+		lead := l.size*1.4 + 0.5
+		if l.size == 0 {
+			lead = 8
+		}
+		if y-lead < pdfMargin {
+			pages = append(pages, page.String())
+			page.Reset()
+			y = pdfPageHeight - pdfMargin
+		}
+		y -= lead
+		if l.text == "" {
+			continue
+		}
+		font := "F1"
+		if l.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(&page, "BT /%s %g Tf %d %.1f Td %s Tj ET\n", font, l.size, pdfMargin, y, pdfString(l.text))
+	}
+	pages = append(pages, page.String())
+
+	// Objects: 1 catalog, 2 page tree, 3-4 fonts, then a page and its
+	// content stream for each page
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, content := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
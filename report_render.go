@@ -0,0 +1,133 @@
+package main
+
+import (
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var reportFuncs = map[string]interface{}{
+	"title":   title,
+	"score":   formatScore,
+	"average": formatAverages,
+	"change":  formatChange,
+	"percent": func(f float64) int { return int(f*100 + 0.5) },
+}
+
+func formatScore(f float64) string {
+	if f == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatAverages lists a module's average scores, naming the exams when
+// there is more than one.
+func formatAverages(averages []ExamAverage) string {
+	switch len(averages) {
+	case 0:
+		return "-"
+	case 1:
+		return formatScore(averages[0].Average)
+	}
+	parts := make([]string, len(averages))
+	for i, a := range averages {
+		parts[i] = formatScore(a.Average) + " " + a.Exam
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatChange(f float64) string {
+	if f > 0 {
+		return "+" + strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+var markdownReport = template.Must(template.New("report.md").Funcs(reportFuncs).Parse(`# {{.Title}}
+
+Generated {{.GeneratedAt}}
+
+## Summary
+
+- Sessions: {{.Sessions}}
+- Minutes studied: {{.TotalMinutes}}
+- Active days: {{.ActiveDays}} of {{.Days}}
+- Current streak: {{.CurrentStreak}} days
+- Longest streak in range: {{.LongestStreak}} days
+
+## Modules
+{{if .Modules}}
+| Module | Minutes | Sessions | Avg score |
+|---|---|---|---|
+{{range .Modules}}| {{title .Module}} | {{.Minutes}} | {{.Sessions}} | {{average .AvgScores}} |
+{{end}}{{else}}
+No sessions logged.
+{{end}}
+## Score Trend
+{{if .Scores}}
+| Skill | First | Last | Change | Scores |
+|---|---|---|---|---|
+{{range .Scores}}| {{title .Module}} | {{score .First}} | {{score .Last}} | {{change .Change}} | {{.Count}} |
+{{end}}{{else}}
+No scores recorded.
+{{end}}
+## Vocabulary
+
+{{len .VocabularyAdded}} words added.{{range .VocabularyAdded}}
+- {{.}}{{end}}
+
+## Homework
+
+{{.Homework.Completed}} of {{.Homework.Set}} completed ({{percent .Homework.Rate}}%).
+`))
+
+var htmlReport = htmltemplate.Must(htmltemplate.New("report.html").Funcs(reportFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 720px; margin: 2em auto; color: #1b2636; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: 4px 8px; text-align: left; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="muted">Generated {{.GeneratedAt}}</p>
+
+<h2>Summary</h2>
+<ul>
+<li>Sessions: {{.Sessions}}</li>
+<li>Minutes studied: {{.TotalMinutes}}</li>
+<li>Active days: {{.ActiveDays}} of {{.Days}}</li>
+<li>Current streak: {{.CurrentStreak}} days</li>
+<li>Longest streak in range: {{.LongestStreak}} days</li>
+</ul>
+
+<h2>Modules</h2>
+{{if .Modules}}<table>
+<tr><th>Module</th><th>Minutes</th><th>Sessions</th><th>Avg score</th></tr>
+{{range .Modules}}<tr><td>{{title .Module}}</td><td>{{.Minutes}}</td><td>{{.Sessions}}</td><td>{{average .AvgScores}}</td></tr>
+{{end}}</table>{{else}}<p>No sessions logged.</p>{{end}}
+
+<h2>Score Trend</h2>
+{{if .Scores}}<table>
+<tr><th>Skill</th><th>First</th><th>Last</th><th>Change</th><th>Scores</th></tr>
+{{range .Scores}}<tr><td>{{title .Module}}</td><td>{{score .First}}</td><td>{{score .Last}}</td><td>{{change .Change}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>{{else}}<p>No scores recorded.</p>{{end}}
+
+<h2>Vocabulary</h2>
+<p>{{len .VocabularyAdded}} words added.</p>
+{{if .VocabularyAdded}}<ul>
+{{range .VocabularyAdded}}<li>{{.}}</li>
+{{end}}</ul>{{end}}
+
+<h2>Homework</h2>
+<p>{{.Homework.Completed}} of {{.Homework.Set}} completed ({{percent .Homework.Rate}}%).</p>
+</body>
+</html>
+`))
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"Engress/scoring"
+)
+
+func TestBuildReport(t *testing.T) {
+	report, err := buildReport(fixtureState(), "2026-10-16", "2026-10-17", fixtureNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Sessions != 3 || report.TotalMinutes != 115 || report.ActiveDays != 2 {
+		t.Errorf("got %d sessions, %d minutes on %d days, want 3, 115 and 2", report.Sessions, report.TotalMinutes, report.ActiveDays)
+	}
+	for _, m := range report.Modules {
+		if m.Module != "writing" {
+			continue
+		}
+		// 6.25 rounds half up to one decimal
+		if len(m.AvgScores) != 1 || m.AvgScores[0].Average != 6.3 || m.AvgScores[0].Sessions != 2 {
+			t.Errorf("got writing averages %+v, want 6.3 over 2 sessions", m.AvgScores)
+		}
+	}
+}
+
+// Scores from different exams are averaged apart, not mixed.
+func TestBuildReportAveragesPerExam(t *testing.T) {
+	state := fixtureState()
+	toefl := fixtureSession("writing", 30, 24, 0, 13)
+	toefl.ExamType = scoring.TOEFL
+	state.DailyLogs = append(state.DailyLogs, toefl)
+
+	report, err := buildReport(state, "2026-10-16", "2026-10-17", fixtureNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range report.Modules {
+		if m.Module == "writing" && (len(m.AvgScores) != 2 || m.AvgScores[0].Average != 6.3 || m.AvgScores[1].Average != 24) {
+			t.Errorf("got writing averages %+v, want 6.3 for IELTS and 24 for TOEFL", m.AvgScores)
+		}
+	}
+}
@@ -3,7 +3,14 @@
 # Engress Database Reset Script
 # This will remove all your data, including logs, vocabulary, and test date.
 
-DATA_PATH="$HOME/Library/Application Support/Engress/data.json"
+DATA_DIR="$ENGRESS_DATA_DIR"
+if [ -z "$DATA_DIR" ]; then
+    case "$(uname -s)" in
+        Darwin) DATA_DIR="$HOME/Library/Application Support/Engress" ;;
+        *) DATA_DIR="${XDG_DATA_HOME:-$HOME/.local/share}/engress" ;;
+    esac
+fi
+DATA_PATH="$DATA_DIR/engress.db"
 
 echo "ENGRESS: Focus Reset Protocol Initiated..."
 
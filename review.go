@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Review grades, as on Anki's buttons.
+const (
+	GradeAgain = 1 // Forgotten
+	GradeHard  = 2
+	GradeGood  = 3
+	GradeEasy  = 4
+)
+
+const (
+	defaultEase = 2.5
+	minEase     = 1.3
+	// newReviewsPerDay caps how many never-reviewed words are introduced a day.
+	newReviewsPerDay = 20
+	// minReviewsForActiveDay is how many reviews make a day count as active
+	// for streaks when no session was logged.
+	minReviewsForActiveDay = 10
+	// reviewStatsDays is how many days of counts GetReviewStats returns.
+	reviewStatsDays = 30
+	// matureInterval is the interval from which a word counts as learned.
+	matureInterval = 21
+)
+
+// ReviewState is a word's spaced-repetition schedule, following SM-2 with
+// Anki's four grades.
+type ReviewState struct {
+	Ease     float64        `json:"ease"`     // 0 until the first review
+	Interval int            `json:"interval"` // Days from the last review to Due
+	Due      string         `json:"due"`      // "2006-01-02"; empty for a new word
+	Reps     int            `json:"reps"`     // Successful reviews since the last lapse
+	Lapses   int            `json:"lapses"`   // Times graded Again after being learned
+	History  []ReviewRecord `json:"history"`
+}
+
+// ReviewRecord is one review of a word.
+type ReviewRecord struct {
+	At       string  `json:"at"` // RFC3339
+	Grade    int     `json:"grade"`
+	Interval int     `json:"interval"` // The interval the grade set
+	Ease     float64 `json:"ease"`
+}
+
+// DailyReviews is how many reviews were done on a day.
+type DailyReviews struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// ReviewStats summarizes the review queue and recent review activity.
+type ReviewStats struct {
+	Due           int            `json:"due"` // Learned words due today or earlier
+	New           int            `json:"new"` // New words still to introduce today
+	ReviewedToday int            `json:"reviewed_today"`
+	Learned       int            `json:"learned"` // Words with an interval of matureInterval days or more
+	Daily         []DailyReviews `json:"daily"`   // The last reviewStatsDays days, oldest first
+}
+
+// schedule applies a grade given at now to a word's review state.
+func (s ReviewState) schedule(grade int, now time.Time) ReviewState {
+	if s.Ease == 0 {
+		s.Ease = defaultEase
+	}
+	switch grade {
+	case GradeAgain:
+		if s.Reps > 0 {
+			s.Lapses++
+		}
+		s.Reps = 0
+		s.Interval = 1
+		s.Ease -= 0.2
+	case GradeHard:
+		s.Interval = int(math.Max(1, math.Round(float64(s.Interval)*1.2)))
+		s.Reps++
+		s.Ease -= 0.15
+	case GradeGood, GradeEasy:
+		switch s.Reps {
+		case 0:
+			s.Interval = 1
+		case 1:
+			s.Interval = 6
+		default:
+			s.Interval = int(math.Round(float64(s.Interval) * s.Ease))
+		}
+		if grade == GradeEasy {
+			s.Interval = int(math.Round(float64(s.Interval)*1.3)) + 1
+			s.Ease += 0.15
+		}
+		s.Reps++
+	}
+	s.Ease = math.Max(minEase, math.Round(s.Ease*100)/100)
+	s.Due = now.AddDate(0, 0, s.Interval).Format(dayLayout)
+	s.History = append(s.History, ReviewRecord{At: now.Format(time.RFC3339), Grade: grade, Interval: s.Interval, Ease: s.Ease})
+	return s
+}
+
+// reviewCounts returns the number of reviews done on each day.
+func reviewCounts(vocab []VocabItem, loc *time.Location) map[string]int {
+	counts := make(map[string]int)
+	for _, item := range vocab {
+		for _, r := range item.Review.History {
+			if at, err := time.Parse(time.RFC3339, r.At); err == nil {
+				counts[at.In(loc).Format(dayLayout)]++
+			}
+		}
+	}
+	return counts
+}
+
+// introducedOn reports whether a word was first reviewed on day.
+func (item VocabItem) introducedOn(day string, loc *time.Location) bool {
+	if len(item.Review.History) == 0 {
+		return false
+	}
+	at, err := time.Parse(time.RFC3339, item.Review.History[0].At)
+	return err == nil && at.In(loc).Format(dayLayout) == day
+}
+
+// dueReviews returns the words due today, most overdue first, followed by
+// as many new words, oldest first, as today's new-word allowance leaves.
+func dueReviews(vocab []VocabItem, loc *time.Location, now time.Time) []VocabItem {
+	today := now.In(loc).Format(dayLayout)
+	due := []VocabItem{}
+	var fresh []VocabItem
+	introduced := 0
+	for _, item := range vocab {
+		switch {
+		case item.Review.Due == "":
+			fresh = append(fresh, item)
+		case item.Review.Due <= today:
+			due = append(due, item)
+		}
+		if item.introducedOn(today, loc) {
+			introduced++
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].Review.Due < due[j].Review.Due })
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].DateAdded+fresh[i].Time < fresh[j].DateAdded+fresh[j].Time
+	})
+	if allowance := newReviewsPerDay - introduced; len(fresh) > allowance {
+		fresh = fresh[:max(allowance, 0)]
+	}
+	return append(due, fresh...)
+}
+
+func reviewStats(vocab []VocabItem, loc *time.Location, now time.Time) ReviewStats {
+	now = now.In(loc)
+	today := now.Format(dayLayout)
+	counts := reviewCounts(vocab, loc)
+	s := ReviewStats{ReviewedToday: counts[today], Daily: []DailyReviews{}}
+	for _, item := range dueReviews(vocab, loc, now) {
+		if item.Review.Due == "" {
+			s.New++
+		} else {
+			s.Due++
+		}
+	}
+	for _, item := range vocab {
+		if item.Review.Interval >= matureInterval {
+			s.Learned++
+		}
+	}
+	for i := reviewStatsDays - 1; i >= 0; i-- {
+		day := now.AddDate(0, 0, -i).Format(dayLayout)
+		s.Daily = append(s.Daily, DailyReviews{Date: day, Count: counts[day]})
+	}
+	return s
+}
+
+// GetDueReviews returns the words to review now: those due, then new ones.
+func (a *App) GetDueReviews() ([]VocabItem, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return dueReviews(state.Vocabulary, state.UserProfile.location(), time.Now()), nil
+}
+
+// SubmitReview grades a review of the word with the given ID (1 again,
+// 2 hard, 3 good, 4 easy) and returns its new schedule.
+func (a *App) SubmitReview(id string, grade int) (ReviewState, error) {
+	if grade < GradeAgain || grade > GradeEasy {
+		return ReviewState{}, &ValidationError{Field: "grade", Message: fmt.Sprintf("must be between %d and %d", GradeAgain, GradeEasy)}
+	}
+	state, err := a.state.LoadState()
+	if err != nil {
+		return ReviewState{}, err
+	}
+	now := time.Now().In(state.UserProfile.location())
+	var review ReviewState
+	err = a.state.UpdateVocab(id, func(item *VocabItem) error {
+		item.Review = item.Review.schedule(grade, now)
+		review = item.Review
+		return nil
+	})
+	return review, err
+}
+
+// GetReviewStats returns the review queue size and daily review counts.
+func (a *App) GetReviewStats() (ReviewStats, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return ReviewStats{}, err
+	}
+	return reviewStats(state.Vocabulary, state.UserProfile.location(), time.Now()), nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"Engress/scoring"
+)
+
+// skills are the four sections every supported exam scores, in the order
+// the IELTS report lists them.
+var skills = []string{scoring.Listening, scoring.Reading, scoring.Writing, scoring.Speaking}
+
+// recentScoreWindow is how many of the latest scores per skill the
+// projection averages over.
+const recentScoreWindow = 3
+
+// get returns the band for skill ("reading", ...), or 0 if unknown.
+func (s Scores) get(skill string) float64 {
+	switch strings.ToLower(skill) {
+	case "reading":
+		return s.Reading
+	case "writing":
+		return s.Writing
+	case "listening":
+		return s.Listening
+	case "speaking":
+		return s.Speaking
+	}
+	return 0
+}
+
+func (s *Scores) set(skill string, band float64) {
+	switch strings.ToLower(skill) {
+	case "reading":
+		s.Reading = band
+	case "writing":
+		s.Writing = band
+	case "listening":
+		s.Listening = band
+	case "speaking":
+		s.Speaking = band
+	}
+}
+
+func (s Scores) isZero() bool {
+	return s == Scores{}
+}
+
+// overall combines the section scores by the exam's rule (for IELTS, the
+// mean rounded to the nearest half band with .25 and .75 rounding up). It
+// returns false unless every section has a score.
+func (s Scores) overall(exam scoring.Exam) (float64, bool) {
+	sections := make(map[string]float64, len(skills))
+	for _, skill := range skills {
+		sections[skill] = s.get(skill)
+	}
+	return exam.OverallScore(sections)
+}
+
+// logScores returns the per-skill scores a session recorded: the explicit
+// Scores of a mock test, or Score for a single-skill session.
+func (log DailyLog) logScores() Scores {
+	if !log.Scores.isZero() {
+		return log.Scores
+	}
+	var s Scores
+	if log.Score > 0 {
+		s.set(log.Module, log.Score)
+	}
+	return s
+}
+
+// fillOverallScore sets Score to the overall score when a mock test
+// recorded all four sections but no overall score.
+func (log *DailyLog) fillOverallScore() {
+	if log.Score != 0 {
+		return
+	}
+	if overall, ok := log.Scores.overall(scoring.MustLookup(log.ExamType)); ok {
+		log.Score = overall
+	}
+}
+
+// ScorePoint is one entry in a score time series.
+type ScorePoint struct {
+	Date  string  `json:"date"` // "2006-01-02" in the profile timezone
+	Score float64 `json:"score"`
+	LogID string  `json:"log_id"`
+}
+
+// ScoreHistory is the per-skill score history for the profile's exam plus
+// a projection of the overall score from recent form.
+type ScoreHistory struct {
+	ExamType string `json:"exam_type"`
+
+	Listening []ScorePoint `json:"listening"`
+	Reading   []ScorePoint `json:"reading"`
+	Writing   []ScorePoint `json:"writing"`
+	Speaking  []ScorePoint `json:"speaking"`
+	Overall   []ScorePoint `json:"overall"` // Each time all four skills had a score
+
+	Recent        Scores   `json:"recent"`         // Mean of the last few scores per skill
+	Projected     float64  `json:"projected"`      // Overall band from Recent; 0 if a skill has no data
+	MissingSkills []string `json:"missing_skills"` // Skills with no score yet
+	Target        float64  `json:"target"`
+	Gap           float64  `json:"gap"` // Target - Projected
+	OnTrack       bool     `json:"on_track"`
+}
+
+func (h *ScoreHistory) series(skill string) *[]ScorePoint {
+	switch skill {
+	case "reading":
+		return &h.Reading
+	case "writing":
+		return &h.Writing
+	case "listening":
+		return &h.Listening
+	default:
+		return &h.Speaking
+	}
+}
+
+// sortedLogs returns logs ordered by session start.
+func sortedLogs(logs []DailyLog, loc *time.Location) []DailyLog {
+	sorted := append([]DailyLog(nil), logs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].sessionStart(loc).Before(sorted[j].sessionStart(loc))
+	})
+	return sorted
+}
+
+// buildScoreHistory follows the profile's exam. Logs from another exam
+// family are on a different scale and are left out; IELTS Academic and
+// General Training share bands, so they are combined.
+func buildScoreHistory(logs []DailyLog, profile UserProfile) ScoreHistory {
+	loc := profile.location()
+	exam := scoring.MustLookup(profile.ExamType)
+	h := ScoreHistory{
+		ExamType:      exam.ID,
+		Listening:     []ScorePoint{},
+		Reading:       []ScorePoint{},
+		Writing:       []ScorePoint{},
+		Speaking:      []ScorePoint{},
+		Overall:       []ScorePoint{},
+		MissingSkills: []string{},
+		Target:        profile.TargetScore,
+	}
+
+	var latest Scores
+	for _, log := range sortedLogs(logs, loc) {
+		if scoring.MustLookup(log.ExamType).Family != exam.Family {
+			continue
+		}
+		scores := log.logScores()
+		if scores.isZero() {
+			continue
+		}
+		day := log.day(loc)
+		for _, skill := range skills {
+			if band := scores.get(skill); band > 0 {
+				series := h.series(skill)
+				*series = append(*series, ScorePoint{Date: day, Score: band, LogID: log.ID})
+				latest.set(skill, band)
+			}
+		}
+		if overall, ok := latest.overall(exam); ok {
+			h.Overall = append(h.Overall, ScorePoint{Date: day, Score: overall, LogID: log.ID})
+		}
+	}
+
+	for _, skill := range skills {
+		series := *h.series(skill)
+		if len(series) == 0 {
+			h.MissingSkills = append(h.MissingSkills, skill)
+			continue
+		}
+		if len(series) > recentScoreWindow {
+			series = series[len(series)-recentScoreWindow:]
+		}
+		sum := 0.0
+		for _, p := range series {
+			sum += p.Score
+		}
+		h.Recent.set(skill, exam.ScaleFor(skill).Round(sum/float64(len(series))))
+	}
+
+	if projected, ok := h.Recent.overall(exam); ok {
+		h.Projected = projected
+		h.Gap = h.Target - h.Projected
+		h.OnTrack = h.Gap <= 0
+	}
+	return h
+}
+
+// GetScoreHistory returns per-skill score history and the projected overall
+// score compared against the target score.
+func (a *App) GetScoreHistory() (ScoreHistory, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return ScoreHistory{}, err
+	}
+	return buildScoreHistory(state.DailyLogs, state.UserProfile), nil
+}
+
+// UpdateExamType switches the exam the profile prepares for, along with a
+// target score on that exam's overall scale.
+func (a *App) UpdateExamType(examType string, targetScore float64) error {
+	exam, ok := scoring.Lookup(examType)
+	if !ok || examType == "" {
+		return &ValidationError{Field: "exam_type", Message: fmt.Sprintf("unknown exam type %q", examType)}
+	}
+	if !exam.Overall.Valid(targetScore) {
+		return &ValidationError{Field: "target_score", Message: fmt.Sprintf("%s scores run from %s", exam.Name, exam.Overall)}
+	}
+	return a.state.UpdateProfile(func(p *UserProfile) error {
+		p.ExamType = examType
+		p.TargetScore = targetScore
+		return nil
+	})
+}
+
+// ConvertRawScore converts a raw score (questions correct) on a section to
+// the exam's scale, e.g. 30/40 on IELTS Academic reading to band 7.
+func (a *App) ConvertRawScore(examType string, section string, raw int) (float64, error) {
+	exam, ok := scoring.Lookup(examType)
+	if !ok {
+		return 0, &ValidationError{Field: "exam_type", Message: fmt.Sprintf("unknown exam type %q", examType)}
+	}
+	sec, ok := exam.Section(section)
+	if !ok {
+		return 0, &ValidationError{Field: "section", Message: fmt.Sprintf("%s has no %q section", exam.Name, section)}
+	}
+	score, err := sec.Convert(raw)
+	if err != nil {
+		return 0, &ValidationError{Field: "raw", Message: err.Error()}
+	}
+	return score, nil
+}
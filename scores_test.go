@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"Engress/scoring"
+)
+
+func TestScoresOverall(t *testing.T) {
+	exam := scoring.MustLookup(scoring.IELTSAcademic)
+	tests := []struct {
+		scores Scores
+		want   float64
+		ok     bool
+	}{
+		{Scores{Listening: 6, Reading: 6.5, Writing: 6, Speaking: 6.5}, 6.5, true},
+		{Scores{Listening: 6, Reading: 6, Writing: 6, Speaking: 6.5}, 6, true},
+		{Scores{Listening: 7, Reading: 7, Writing: 6.5, Speaking: 6.5}, 7, true},
+		{Scores{Listening: 7, Reading: 7, Writing: 7, Speaking: 6.5}, 7, true},
+		// No speaking score, so no overall band
+		{Scores{Listening: 7, Reading: 7, Writing: 7}, 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.scores.overall(exam); got != tt.want || ok != tt.ok {
+			t.Errorf("%+v = %v, %v; want %v, %v", tt.scores, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// A mock test missing a section keeps its score empty
+	log := DailyLog{Module: "mock", Scores: Scores{Listening: 7, Reading: 7, Writing: 7}}
+	log.fillOverallScore()
+	if log.Score != 0 {
+		t.Errorf("overall %v filled in from three sections", log.Score)
+	}
+}
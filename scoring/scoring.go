@@ -0,0 +1,172 @@
+// Package scoring knows the section layout, score scales and raw-score
+// conversion tables of the English proficiency exams Engress tracks.
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Exam type identifiers, as stored on profiles and session logs.
+const (
+	IELTSAcademic = "ielts_academic"
+	IELTSGeneral  = "ielts_general"
+	TOEFL         = "toefl_ibt"
+	PTE           = "pte_academic"
+)
+
+// Default is the exam assumed when none is recorded; every log written
+// before exam types existed was an IELTS Academic session.
+const Default = IELTSAcademic
+
+// Sections, lowercased to match session modules.
+const (
+	Listening = "listening"
+	Reading   = "reading"
+	Writing   = "writing"
+	Speaking  = "speaking"
+)
+
+var ErrNoConversion = errors.New("scoring: no raw score conversion for this section")
+
+// Scale is a score range and the increment scores move in.
+type Scale struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Step float64 `json:"step"`
+}
+
+// Valid reports whether score lies on the scale.
+func (s Scale) Valid(score float64) bool {
+	if score < s.Min || score > s.Max {
+		return false
+	}
+	steps := (score - s.Min) / s.Step
+	return math.Abs(steps-math.Round(steps)) < 1e-9
+}
+
+// Round snaps v to the nearest step, with halves rounding up, and clamps it
+// to the scale.
+func (s Scale) Round(v float64) float64 {
+	r := s.Min + math.Floor((v-s.Min)/s.Step+0.5)*s.Step
+	return math.Max(s.Min, math.Min(s.Max, r))
+}
+
+func (s Scale) String() string {
+	return fmt.Sprintf("%g to %g in steps of %g", s.Min, s.Max, s.Step)
+}
+
+// band is one row of a conversion table: the lowest raw score that earns Score.
+type band struct {
+	Raw   int
+	Score float64
+}
+
+// Section is one scored part of an exam.
+type Section struct {
+	Name  string `json:"name"`
+	Scale Scale  `json:"scale"`
+	// RawMax is the number of questions when raw scores convert via table; 0 otherwise.
+	RawMax int `json:"raw_max"`
+
+	table []band // Descending by Raw
+}
+
+// Convert maps a raw (questions correct) score to the section scale.
+func (s Section) Convert(raw int) (float64, error) {
+	if len(s.table) == 0 {
+		return 0, ErrNoConversion
+	}
+	if raw < 0 || raw > s.RawMax {
+		return 0, fmt.Errorf("scoring: raw %s score must be between 0 and %d", s.Name, s.RawMax)
+	}
+	for _, b := range s.table {
+		if raw >= b.Raw {
+			return b.Score, nil
+		}
+	}
+	return s.Scale.Min, nil
+}
+
+// Exam describes one exam's sections and how their scores combine.
+type Exam struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Family   string    `json:"family"` // "ielts", "toefl" or "pte"; scores compare within a family
+	Sections []Section `json:"sections"`
+	Overall  Scale     `json:"overall"`
+	// Total is true when the overall score is the sum of the sections
+	// (TOEFL) rather than their rounded mean.
+	Total bool `json:"total"`
+}
+
+// Section returns the named section (case-insensitive).
+func (e Exam) Section(name string) (Section, bool) {
+	for _, s := range e.Sections {
+		if strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// ScaleFor returns the scale a score for module is on: the section's own
+// scale for a section module, the overall scale for anything else (mock
+// tests, general practice).
+func (e Exam) ScaleFor(module string) Scale {
+	if s, ok := e.Section(module); ok {
+		return s.Scale
+	}
+	return e.Overall
+}
+
+// OverallScore combines section scores, keyed by section name, into the
+// overall score. It returns false unless every section has a score.
+//
+// IELTS rounds the mean to the nearest half band with .25 and .75 rounding
+// up; PTE rounds the mean to a whole point. Pearson weights PTE's overall
+// from integrated item scores, so the mean is only an approximation.
+func (e Exam) OverallScore(scores map[string]float64) (float64, bool) {
+	sum := 0.0
+	for _, s := range e.Sections {
+		v, ok := scores[s.Name]
+		if !ok || v == 0 {
+			return 0, false
+		}
+		sum += v
+	}
+	if e.Total {
+		return sum, true
+	}
+	return e.Overall.Round(sum / float64(len(e.Sections))), true
+}
+
+// Lookup returns the exam with the given ID; an empty ID is Default.
+func Lookup(id string) (Exam, bool) {
+	if id == "" {
+		id = Default
+	}
+	for _, e := range exams {
+		if e.ID == id {
+			return e, true
+		}
+	}
+	return Exam{}, false
+}
+
+// MustLookup is Lookup for IDs that have already been validated; unknown
+// IDs fall back to Default.
+func MustLookup(id string) Exam {
+	if e, ok := Lookup(id); ok {
+		return e
+	}
+	e, _ := Lookup(Default)
+	return e
+}
+
+// Exams lists every supported exam.
+func Exams() []Exam {
+	return append([]Exam(nil), exams...)
+}
@@ -0,0 +1,183 @@
+package scoring
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		exam, section string
+		raw           int
+		want          float64
+	}{
+		// Listening: the edges of each published band
+		{IELTSAcademic, Listening, 40, 9},
+		{IELTSAcademic, Listening, 39, 9},
+		{IELTSAcademic, Listening, 38, 8.5},
+		{IELTSAcademic, Listening, 30, 7},
+		{IELTSAcademic, Listening, 29, 6.5},
+		{IELTSAcademic, Listening, 18, 5.5},
+		{IELTSAcademic, Listening, 17, 5},
+		{IELTSAcademic, Listening, 16, 5},
+		{IELTSAcademic, Listening, 15, 4.5},
+		{IELTSAcademic, Listening, 13, 4.5},
+		{IELTSAcademic, Listening, 12, 4},
+		{IELTSAcademic, Listening, 10, 4},
+		{IELTSAcademic, Listening, 1, 1},
+		{IELTSAcademic, Listening, 0, 0},
+		{IELTSGeneral, Listening, 16, 5},
+
+		{IELTSAcademic, Reading, 40, 9},
+		{IELTSAcademic, Reading, 33, 7.5},
+		{IELTSAcademic, Reading, 32, 7},
+		{IELTSAcademic, Reading, 15, 5},
+		{IELTSAcademic, Reading, 14, 4.5},
+		{IELTSAcademic, Reading, 10, 4},
+		{IELTSAcademic, Reading, 0, 0},
+
+		{IELTSGeneral, Reading, 40, 9},
+		{IELTSGeneral, Reading, 39, 8.5},
+		{IELTSGeneral, Reading, 34, 7},
+		{IELTSGeneral, Reading, 33, 6.5},
+		{IELTSGeneral, Reading, 23, 5},
+		{IELTSGeneral, Reading, 22, 4.5},
+		{IELTSGeneral, Reading, 15, 4},
+		{IELTSGeneral, Reading, 0, 0},
+	}
+	for _, tt := range tests {
+		section, _ := MustLookup(tt.exam).Section(tt.section)
+		got, err := section.Convert(tt.raw)
+		if err != nil || got != tt.want {
+			t.Errorf("%s %s raw %d = %v, %v; want %v", tt.exam, tt.section, tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	listening, _ := MustLookup(IELTSAcademic).Section(Listening)
+	for _, raw := range []int{-1, 41} {
+		if _, err := listening.Convert(raw); err == nil {
+			t.Errorf("raw %d out of range was converted", raw)
+		}
+	}
+	writing, _ := MustLookup(IELTSAcademic).Section(Writing)
+	if _, err := writing.Convert(5); !errors.Is(err, ErrNoConversion) {
+		t.Errorf("IELTS writing: got %v, want ErrNoConversion", err)
+	}
+	for _, id := range []string{TOEFL, PTE} {
+		for _, s := range MustLookup(id).Sections {
+			if _, err := s.Convert(10); !errors.Is(err, ErrNoConversion) {
+				t.Errorf("%s %s: got %v, want ErrNoConversion", id, s.Name, err)
+			}
+		}
+	}
+}
+
+func TestScaleValid(t *testing.T) {
+	tests := []struct {
+		exam  string
+		score float64
+		want  bool
+	}{
+		{IELTSAcademic, 0, true},
+		{IELTSAcademic, 0.5, true},
+		{IELTSAcademic, 6.5, true},
+		{IELTSAcademic, 9, true},
+		{IELTSAcademic, 9.5, false},
+		{IELTSAcademic, 6.25, false},
+		{IELTSAcademic, -0.5, false},
+		{IELTSAcademic, 30, false},
+
+		{TOEFL, 0, true},
+		{TOEFL, 30, true},
+		{TOEFL, 31, false},
+		{TOEFL, 24.5, false},
+		{TOEFL, -1, false},
+
+		{PTE, 10, true},
+		{PTE, 90, true},
+		{PTE, 9, false},
+		{PTE, 91, false},
+		{PTE, 65.5, false},
+	}
+	for _, tt := range tests {
+		for _, s := range MustLookup(tt.exam).Sections {
+			if got := s.Scale.Valid(tt.score); got != tt.want {
+				t.Errorf("%s %s: Valid(%v) = %v, want %v", tt.exam, s.Name, tt.score, got, tt.want)
+			}
+		}
+	}
+
+	toeflTotal := MustLookup(TOEFL).Overall
+	for score, want := range map[float64]bool{0: true, 120: true, 121: false, 119.5: false} {
+		if got := toeflTotal.Valid(score); got != want {
+			t.Errorf("TOEFL total: Valid(%v) = %v, want %v", score, got, want)
+		}
+	}
+}
+
+func TestOverallScore(t *testing.T) {
+	tests := []struct {
+		exam   string
+		scores map[string]float64
+		want   float64
+		ok     bool
+	}{
+		{IELTSAcademic, map[string]float64{Listening: 6, Reading: 6, Writing: 6, Speaking: 6}, 6, true},
+		{IELTSAcademic, map[string]float64{Listening: 9, Reading: 9, Writing: 9, Speaking: 9}, 9, true},
+		{IELTSAcademic, map[string]float64{Listening: 6, Reading: 6, Writing: 6}, 0, false},
+
+		// TOEFL adds its sections up
+		{TOEFL, map[string]float64{Listening: 30, Reading: 30, Writing: 30, Speaking: 30}, 120, true},
+		{TOEFL, map[string]float64{Listening: 1, Reading: 1, Writing: 1, Speaking: 1}, 4, true},
+		{TOEFL, map[string]float64{Listening: 25, Reading: 24, Writing: 22, Speaking: 23}, 94, true},
+		{TOEFL, map[string]float64{Listening: 25, Reading: 24, Writing: 22}, 0, false},
+
+		// PTE rounds the mean to a whole point, halves up
+		{PTE, map[string]float64{Listening: 10, Reading: 10, Writing: 10, Speaking: 10}, 10, true},
+		{PTE, map[string]float64{Listening: 90, Reading: 90, Writing: 90, Speaking: 90}, 90, true},
+		{PTE, map[string]float64{Listening: 50, Reading: 51, Writing: 50, Speaking: 51}, 51, true},
+		{PTE, map[string]float64{Listening: 50, Reading: 50, Writing: 50, Speaking: 51}, 50, true},
+		{PTE, map[string]float64{Listening: 65, Reading: 65, Writing: 65, Speaking: 0}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := MustLookup(tt.exam).OverallScore(tt.scores)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s %v = %v, %v; want %v, %v", tt.exam, tt.scores, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// IELTS rounds the mean of the four bands to the nearest half band, and a
+// mean ending in .25 or .75 rounds up.
+func TestIELTSOverallRounding(t *testing.T) {
+	tests := []struct {
+		l, r, w, s float64
+		want       float64
+	}{
+		{6, 6.5, 6, 6.5, 6.5},   // 6.25
+		{6, 6, 6, 6.5, 6},       // 6.125
+		{7, 7, 6.5, 6.5, 7},     // 6.75
+		{7, 7, 7, 6.5, 7},       // 6.875
+		{6.5, 6.5, 6.5, 7, 6.5}, // 6.625
+		{6, 6, 6.5, 6.5, 6.5},   // 6.25, two sections each
+	}
+	for _, tt := range tests {
+		scores := map[string]float64{Listening: tt.l, Reading: tt.r, Writing: tt.w, Speaking: tt.s}
+		for _, id := range []string{IELTSAcademic, IELTSGeneral} {
+			if got, ok := MustLookup(id).OverallScore(scores); !ok || got != tt.want {
+				t.Errorf("%s %v = %v, %v; want %v", id, scores, got, ok, tt.want)
+			}
+		}
+	}
+}
+
+func TestScaleRound(t *testing.T) {
+	band := MustLookup(IELTSAcademic).Overall
+	for v, want := range map[float64]float64{6.25: 6.5, 6.125: 6, 6.75: 7, 6.875: 7, 6.74: 6.5, 0.1: 0, 9.2: 9, -1: 0, 10: 9} {
+		if got := band.Round(v); got != want {
+			t.Errorf("Round(%v) = %v, want %v", v, got, want)
+		}
+	}
+}
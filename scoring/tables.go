@@ -0,0 +1,80 @@
+package scoring
+
+var (
+	ieltsBand  = Scale{Min: 0, Max: 9, Step: 0.5}
+	toeflScore = Scale{Min: 0, Max: 30, Step: 1}
+	pteScore   = Scale{Min: 10, Max: 90, Step: 1}
+)
+
+// IELTS raw-to-band tables for the 40-question papers. Bands 4 to 9 are
+// the conversions published in "IELTS scoring in detail" on ielts.org;
+// IDP and the British Council publish the same ones. Below band 4 no table
+// is published, so those rows are an even spread down to band 1. Raw
+// scores below the last row are band 0.
+var (
+	ieltsListening = []band{
+		{39, 9}, {37, 8.5}, {35, 8}, {32, 7.5}, {30, 7}, {26, 6.5}, {23, 6},
+		{18, 5.5}, {16, 5}, {13, 4.5}, {10, 4}, {8, 3.5}, {6, 3}, {4, 2.5}, {2, 2}, {1, 1},
+	}
+	ieltsAcademicReading = []band{
+		{39, 9}, {37, 8.5}, {35, 8}, {33, 7.5}, {30, 7}, {27, 6.5}, {23, 6},
+		{19, 5.5}, {15, 5}, {13, 4.5}, {10, 4}, {8, 3.5}, {6, 3}, {4, 2.5}, {2, 2}, {1, 1},
+	}
+	ieltsGeneralReading = []band{
+		{40, 9}, {39, 8.5}, {37, 8}, {36, 7.5}, {34, 7}, {32, 6.5}, {30, 6},
+		{27, 5.5}, {23, 5}, {19, 4.5}, {15, 4}, {12, 3.5}, {9, 3}, {6, 2.5}, {3, 2}, {1, 1},
+	}
+)
+
+func ieltsSections(reading []band) []Section {
+	return []Section{
+		{Name: Listening, Scale: ieltsBand, RawMax: 40, table: ieltsListening},
+		{Name: Reading, Scale: ieltsBand, RawMax: 40, table: reading},
+		{Name: Writing, Scale: ieltsBand},
+		{Name: Speaking, Scale: ieltsBand},
+	}
+}
+
+// TOEFL and PTE report scaled section scores directly and publish no raw
+// conversion tables, so their sections have none.
+var exams = []Exam{
+	{
+		ID:       IELTSAcademic,
+		Name:     "IELTS Academic",
+		Family:   "ielts",
+		Sections: ieltsSections(ieltsAcademicReading),
+		Overall:  ieltsBand,
+	},
+	{
+		ID:       IELTSGeneral,
+		Name:     "IELTS General Training",
+		Family:   "ielts",
+		Sections: ieltsSections(ieltsGeneralReading),
+		Overall:  ieltsBand,
+	},
+	{
+		ID:     TOEFL,
+		Name:   "TOEFL iBT",
+		Family: "toefl",
+		Sections: []Section{
+			{Name: Reading, Scale: toeflScore},
+			{Name: Listening, Scale: toeflScore},
+			{Name: Speaking, Scale: toeflScore},
+			{Name: Writing, Scale: toeflScore},
+		},
+		Overall: Scale{Min: 0, Max: 120, Step: 1},
+		Total:   true,
+	},
+	{
+		ID:     PTE,
+		Name:   "PTE Academic",
+		Family: "pte",
+		Sections: []Section{
+			{Name: Speaking, Scale: pteScore},
+			{Name: Writing, Scale: pteScore},
+			{Name: Reading, Scale: pteScore},
+			{Name: Listening, Scale: pteScore},
+		},
+		Overall: pteScore,
+	},
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"Engress/scoring"
+)
+
+// Exam types a session or profile can target. An empty exam type is
+// treated as IELTS Academic, which is what every log before exam types assumed.
+const (
+	ExamIELTSAcademic = scoring.IELTSAcademic
+	ExamIELTSGeneral  = scoring.IELTSGeneral
+	ExamTOEFL         = scoring.TOEFL
+	ExamPTE           = scoring.PTE
+)
+
+// knownModules are the session categories the frontend logs, lowercased.
+var knownModules = []string{"writing", "speaking", "reading", "listening", "vocabulary", "mockup", "general"}
+
+// subTasks lists the valid sub-tasks per module; modules not listed take none.
+var subTasks = map[string][]string{
+	"writing":  {"task1", "task2"},
+	"speaking": {"part1", "part2", "part3"},
+}
+
+// SessionInput is everything LogSession needs to record a session.
+type SessionInput struct {
+	Module     string  `json:"module"`
+	Duration   int     `json:"duration"` // Minutes
+	Score      float64 `json:"score"`    // On the exam's scale for the module; 0 if not scored
+	Reflection string  `json:"reflection"`
+	Homework   string  `json:"homework"`
+	Learnings  string  `json:"learnings"`
+	Content    string  `json:"content"`
+	SourceURL  string  `json:"source_url"`
+	Screenshot string  `json:"screenshot"`
+	Scores     Scores  `json:"scores"` // Per-section scores, for mock tests
+
+	ExamType  string `json:"exam_type,omitempty"`  // Defaults to the profile's exam
+	SubTask   string `json:"sub_task,omitempty"`   // "task1", "part2", ...
+	StartedAt string `json:"started_at,omitempty"` // RFC3339
+	EndedAt   string `json:"ended_at,omitempty"`   // RFC3339
+}
+
+// normalizeModule returns module as stored: lowercased, without spaces.
+func normalizeModule(module string) string {
+	return strings.ToLower(strings.TrimSpace(module))
+}
+
+func validateModule(module string) error {
+	m := normalizeModule(module)
+	if m == "" {
+		return &ValidationError{Field: "module", Message: "is required"}
+	}
+	for _, known := range knownModules {
+		if m == known {
+			return nil
+		}
+	}
+	return &ValidationError{Field: "module", Message: fmt.Sprintf("unknown module %q", module)}
+}
+
+func validateSubTask(module, subTask string) error {
+	if subTask == "" {
+		return nil
+	}
+	for _, t := range subTasks[normalizeModule(module)] {
+		if subTask == t {
+			return nil
+		}
+	}
+	return &ValidationError{Field: "sub_task", Message: fmt.Sprintf("%q is not a %s sub-task", subTask, module)}
+}
+
+func validateExamType(examType string) error {
+	if _, ok := scoring.Lookup(examType); !ok {
+		return &ValidationError{Field: "exam_type", Message: fmt.Sprintf("unknown exam type %q", examType)}
+	}
+	return nil
+}
+
+// validateScore checks score against the exam's scale for module; zero
+// means the session wasn't scored.
+func validateScore(examType, module string, score float64) error {
+	return checkScale("score", scoring.MustLookup(examType), module, score)
+}
+
+// validateScores checks each section score; zero means the section wasn't scored.
+func validateScores(examType string, scores Scores) error {
+	exam := scoring.MustLookup(examType)
+	for _, skill := range skills {
+		if err := checkScale("scores."+skill, exam, skill, scores.get(skill)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkScale(field string, exam scoring.Exam, module string, score float64) error {
+	if score == 0 {
+		return nil
+	}
+	if scale := exam.ScaleFor(module); !scale.Valid(score) {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("%s scores run from %s", exam.Name, scale)}
+	}
+	return nil
+}
+
+// sessionTimes parses the optional start and end timestamps.
+func (in SessionInput) sessionTimes() (started, ended time.Time, err error) {
+	if in.StartedAt != "" {
+		if started, err = time.Parse(time.RFC3339, in.StartedAt); err != nil {
+			return started, ended, &ValidationError{Field: "started_at", Message: "must be an RFC3339 timestamp"}
+		}
+	}
+	if in.EndedAt != "" {
+		if ended, err = time.Parse(time.RFC3339, in.EndedAt); err != nil {
+			return started, ended, &ValidationError{Field: "ended_at", Message: "must be an RFC3339 timestamp"}
+		}
+	}
+	if !started.IsZero() && !ended.IsZero() && ended.Before(started) {
+		return started, ended, &ValidationError{Field: "ended_at", Message: "is before started_at"}
+	}
+	return started, ended, nil
+}
+
+// clockSkew is how far past now a session may end, for a frontend whose
+// clock runs slightly ahead.
+const clockSkew = time.Minute
+
+// Validate checks the input and returns a *ValidationError for the first problem.
+func (in SessionInput) Validate() error {
+	return in.validate(time.Now())
+}
+
+func (in SessionInput) validate(now time.Time) error {
+	if err := validateModule(in.Module); err != nil {
+		return err
+	}
+	if in.Duration < 1 {
+		return &ValidationError{Field: "duration", Message: "must be at least a minute"}
+	}
+	if err := validateExamType(in.ExamType); err != nil {
+		return err
+	}
+	if err := validateScore(in.ExamType, in.Module, in.Score); err != nil {
+		return err
+	}
+	if err := validateScores(in.ExamType, in.Scores); err != nil {
+		return err
+	}
+	if err := validateSubTask(in.Module, in.SubTask); err != nil {
+		return err
+	}
+	started, ended, err := in.sessionTimes()
+	if err != nil {
+		return err
+	}
+	if limit := now.Add(clockSkew); started.After(limit) {
+		return &ValidationError{Field: "started_at", Message: "is in the future"}
+	} else if ended.After(limit) {
+		return &ValidationError{Field: "ended_at", Message: "is in the future"}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"Engress/scoring"
+)
+
+func TestSessionInputValidate(t *testing.T) {
+	at := func(d time.Duration) string { return fixtureNow.Add(d).Format(time.RFC3339) }
+	tests := []struct {
+		name  string
+		in    SessionInput
+		field string // "" if valid
+	}{
+		{"valid", SessionInput{Module: "reading", Duration: 30, Score: 7}, ""},
+		{"module in any case", SessionInput{Module: " Writing ", Duration: 30, SubTask: "task2"}, ""},
+		{"no module", SessionInput{Duration: 30}, "module"},
+		{"unknown module", SessionInput{Module: "grammar", Duration: 30}, "module"},
+		{"negative duration", SessionInput{Module: "reading", Duration: -5}, "duration"},
+		{"zero duration", SessionInput{Module: "reading"}, "duration"},
+		{"band above 9", SessionInput{Module: "reading", Duration: 30, Score: 9.5}, "score"},
+		{"band off the half-band steps", SessionInput{Module: "reading", Duration: 30, Score: 6.3}, "score"},
+		{"raw score", SessionInput{Module: "reading", Duration: 30, Score: 35}, "score"},
+		{"TOEFL section above 30", SessionInput{Module: "reading", Duration: 30, Score: 31, ExamType: scoring.TOEFL}, "score"},
+		{"TOEFL mock total", SessionInput{Module: "mockup", Duration: 120, Score: 100, ExamType: scoring.TOEFL}, ""},
+		{"section score outside the scale", SessionInput{Module: "mockup", Duration: 120, Scores: Scores{Listening: 10}}, "scores.listening"},
+		{"unknown exam", SessionInput{Module: "reading", Duration: 30, ExamType: "cambridge"}, "exam_type"},
+		{"sub-task of another module", SessionInput{Module: "reading", Duration: 30, SubTask: "task1"}, "sub_task"},
+		{"ended just now", SessionInput{Module: "reading", Duration: 30, StartedAt: at(-30 * time.Minute), EndedAt: at(0)}, ""},
+		{"started in the future", SessionInput{Module: "reading", Duration: 30, StartedAt: at(24 * time.Hour)}, "started_at"},
+		{"ends in the future", SessionInput{Module: "reading", Duration: 30, StartedAt: at(-10 * time.Minute), EndedAt: at(20 * time.Minute)}, "ended_at"},
+		{"ends before it starts", SessionInput{Module: "reading", Duration: 30, StartedAt: at(-10 * time.Minute), EndedAt: at(-40 * time.Minute)}, "ended_at"},
+		{"bad timestamp", SessionInput{Module: "reading", Duration: 30, StartedAt: "yesterday"}, "started_at"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.in.validate(fixtureNow)
+			var verr *ValidationError
+			switch {
+			case tt.field == "" && err != nil:
+				t.Errorf("got %v, want no error", err)
+			case tt.field != "" && (!errors.As(err, &verr) || verr.Field != tt.field):
+				t.Errorf("got %v, want a %s error", err, tt.field)
+			}
+		})
+	}
+}
+
+// LogSession stores the module lowercased, however the caller spelled it.
+func TestLogSessionLowercasesModule(t *testing.T) {
+	app := fixtureApp(t, fixtureState())
+	blobs, err := NewBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.blobs = blobs
+	id, err := app.LogSession(SessionInput{Module: "Writing", Duration: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := app.state.GetLog(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.Module != "writing" {
+		t.Errorf("stored module %q, want %q", log.Module, "writing")
+	}
+}
@@ -1,57 +1,40 @@
 package main
 
-import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-)
+import "path/filepath"
 
-func (a *App) getStoragePath() string {
-	home, _ := os.UserHomeDir()
-	path := filepath.Join(home, "Library", "Application Support", "Engress")
-	os.MkdirAll(path, 0755)
-	return filepath.Join(path, "data.json")
-}
+// OpenDefaultStore opens the database in dir, migrating the legacy
+// data.json into it on first run, and takes a rolling backup. If the
+// database is damaged the newest readable backup is restored instead and
+// the returned notice describes what happened; it is empty otherwise.
+func OpenDefaultStore(dir string) (Store, string, error) {
+	dbPath := filepath.Join(dir, "engress.db")
+	legacyJSON := filepath.Join(dir, "data.json")
 
-func (a *App) LoadState() (*AppState, error) {
-	path := a.getStoragePath()
-	file, err := os.ReadFile(path)
+	notice := ""
+	store, err := OpenBoltStore(dbPath, legacyJSON)
 	if err != nil {
-		if os.IsNotExist(err) {
-			// Return default state
-			return &AppState{
-				UserProfile: UserProfile{
-					TestDate:        "2026-03-01",
-					TargetScore:     7.5,
-					IsSetupComplete: false,
-					ReminderTimes:   []string{"10:00", "22:00"},
-					ReminderEnabled: true,
-				},
-				DailyLogs: []DailyLog{},
-			}, nil
+		if !isCorruption(err) {
+			return nil, "", err
+		}
+		if store, notice, err = restoreLatestBackup(dbPath, legacyJSON, err); err != nil {
+			return nil, "", err
 		}
-		return nil, err
 	}
 
-	var state AppState
-	err = json.Unmarshal(file, &state)
-	return &state, err
+	keep := 0
+	if state, err := store.LoadState(); err == nil {
+		keep = state.UserProfile.BackupsToKeep
+	}
+	store.Backup(keep)
+	return store, notice, nil
 }
 
-func (a *App) SaveState(state *AppState) error {
-	path := a.getStoragePath()
-	data, err := json.MarshalIndent(state, "", "  ")
+// loadState never returns a nil state; callers that ignore the error
+// get the defaults instead of crashing.
+func (a *App) loadState() (*AppState, error) {
+	state, err := a.state.LoadState()
 	if err != nil {
-		return err
+		return defaultState(), err
 	}
-	return os.WriteFile(path, data, 0644)
-}
-
-func (a *App) GetState() *AppState {
-	state, _ := a.LoadState()
-	return state
-}
-
-func (a *App) SetState(state AppState) {
-	a.SaveState(&state)
+	return state, nil
 }
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"sync"
+)
+
+// RuntimeState is the in-memory session state shared by the scheduler, the
+// HUD watchers and the frontend. It is never persisted.
+type RuntimeState struct {
+	IsPaused             bool   `json:"is_paused"`
+	CurrentCategory      string `json:"current_category"`
+	CurrentTimeStr       string `json:"current_time"`
+	HUDScratchpadVisible bool   `json:"hud_scratchpad_visible"`
+	PauseCounter         int    `json:"-"` // Scheduler ticks spent paused
+	LastHUDNotes         string `json:"-"`
+}
+
+// Events emitted to the frontend whenever the corresponding state changes.
+const (
+	EventStateChanged   = "state-changed"
+	EventRuntimeChanged = "runtime-state-changed"
+)
+
+// StateService is the single owner of the persisted AppState and the
+// RuntimeState. Every mutation goes through its lock, and every change is
+// pushed to the frontend as an event so it doesn't have to poll.
+//
+// It implements Store by wrapping another Store, so code that only needs
+// persistence can keep using the Store interface.
+type StateService struct {
+	mu      sync.Mutex
+	store   Store
+	runtime RuntimeState
+	emit    func(event string, data interface{})
+}
+
+func NewStateService(store Store) *StateService {
+	return &StateService{store: store}
+}
+
+// SetEmitter installs the function used to publish events. Until it is
+// called (i.e. before Wails startup) changes are not published.
+func (s *StateService) SetEmitter(emit func(event string, data interface{})) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emit = emit
+}
+
+// errNoChange tells Do that fn wrote nothing, so there is no new state to
+// publish. Do returns nil for it.
+var errNoChange = errors.New("no change")
+
+// Do runs fn with exclusive access to the underlying store, for changes that
+// need to read and write atomically, then publishes the new state once.
+// fn must not call back into the StateService.
+func (s *StateService) Do(fn func(Store) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := fn(s.store); errors.Is(err, errNoChange) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if s.emit != nil {
+		if state, err := s.store.LoadState(); err == nil {
+			s.emit(EventStateChanged, *state)
+		}
+	}
+	return nil
+}
+
+// Runtime returns a snapshot of the runtime state.
+func (s *StateService) Runtime() RuntimeState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.runtime
+}
+
+// UpdateRuntime applies fn to the runtime state and returns the result.
+func (s *StateService) UpdateRuntime(fn func(*RuntimeState)) RuntimeState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before := s.runtime
+	fn(&s.runtime)
+	if s.emit != nil && publicRuntime(before) != publicRuntime(s.runtime) {
+		s.emit(EventRuntimeChanged, s.runtime)
+	}
+	return s.runtime
+}
+
+// publicRuntime drops the fields that change too often to be worth an event.
+func publicRuntime(r RuntimeState) RuntimeState {
+	r.CurrentTimeStr = ""
+	r.PauseCounter = 0
+	r.LastHUDNotes = ""
+	return r
+}
+
+func (s *StateService) LoadState() (*AppState, error) {
+	return s.store.LoadState()
+}
+
+func (s *StateService) ReplaceState(state *AppState) error {
+	return s.Do(func(st Store) error { return st.ReplaceState(state) })
+}
+
+func (s *StateService) UpdateProfile(fn func(*UserProfile) error) error {
+	return s.Do(func(st Store) error { return st.UpdateProfile(fn) })
+}
+
+func (s *StateService) GetLog(id string) (DailyLog, error) {
+	return s.store.GetLog(id)
+}
+
+func (s *StateService) AddLog(log DailyLog) error {
+	return s.Do(func(st Store) error { return st.AddLog(log) })
+}
+
+func (s *StateService) UpdateLog(id string, fn func(*DailyLog) error) error {
+	return s.Do(func(st Store) error { return st.UpdateLog(id, fn) })
+}
+
+func (s *StateService) DeleteLog(id string) (bool, error) {
+	deleted := false
+	err := s.Do(func(st Store) error {
+		var err error
+		deleted, err = st.DeleteLog(id)
+		return err
+	})
+	return deleted, err
+}
+
+func (s *StateService) AddVocab(item VocabItem) error {
+	return s.Do(func(st Store) error { return st.AddVocab(item) })
+}
+
+func (s *StateService) UpdateVocab(id string, fn func(*VocabItem) error) error {
+	return s.Do(func(st Store) error { return st.UpdateVocab(id, fn) })
+}
+
+func (s *StateService) DeleteVocab(id string) (bool, error) {
+	deleted := false
+	err := s.Do(func(st Store) error {
+		var err error
+		deleted, err = st.DeleteVocab(id)
+		return err
+	})
+	return deleted, err
+}
+
+func (s *StateService) SavePlan(plan *StudyPlan) error {
+	return s.Do(func(st Store) error { return st.SavePlan(plan) })
+}
+
+func (s *StateService) Reset() error {
+	return s.Do(func(st Store) error { return st.Reset() })
+}
+
+func (s *StateService) Backup(keep int) (string, error) {
+	return s.store.Backup(keep)
+}
+
+func (s *StateService) BackupAttachments() ([]string, error) {
+	return s.store.BackupAttachments()
+}
+
+func (s *StateService) Close() error {
+	return s.store.Close()
+}
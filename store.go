@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+
+	"Engress/scoring"
+)
+
+// ErrNotFound is returned when a record with the requested ID does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// Store persists the user profile, session logs and vocabulary.
+// Every write runs in its own transaction, so concurrent callers
+// (the scheduler goroutine and the frontend) never overwrite each other.
+type Store interface {
+	LoadState() (*AppState, error)
+	ReplaceState(state *AppState) error
+	UpdateProfile(fn func(*UserProfile) error) error
+	GetLog(id string) (DailyLog, error)
+	AddLog(log DailyLog) error
+	UpdateLog(id string, fn func(*DailyLog) error) error
+	DeleteLog(id string) (bool, error)
+	AddVocab(item VocabItem) error
+	UpdateVocab(id string, fn func(*VocabItem) error) error
+	DeleteVocab(id string) (bool, error)
+	SavePlan(plan *StudyPlan) error // nil clears the plan
+	Reset() error
+	Backup(keep int) (string, error)
+	BackupAttachments() ([]string, error) // Blob IDs the logs in the kept backups refer to
+	Close() error
+}
+
+// defaultState is what a fresh install starts with.
+func defaultState() *AppState {
+	return &AppState{
+		SchemaVersion: currentSchemaVersion,
+		UserProfile: UserProfile{
+			TestDate:        "2026-03-01",
+			TargetScore:     7.5,
+			IsSetupComplete: false,
+			ReminderTimes:   []string{"10:00", "22:00"},
+			ReminderEnabled: true,
+			Timezone:        systemTimezone(),
+			ExamType:        scoring.Default,
+			StudyTargets:    StudyTargets{DailyMinutes: defaultDailyMinutes},
+		},
+		DailyLogs:  []DailyLog{},
+		Vocabulary: []VocabItem{},
+	}
+}
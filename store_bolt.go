@@ -0,0 +1,387 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	bucketMeta       = []byte("meta")
+	keySchemaVersion = []byte("schema_version")
+	bucketProfile    = []byte("profile")
+	keyProfile       = []byte("user")
+	bucketPlan       = []byte("plan")
+	keyPlan          = []byte("current")
+)
+
+// table is a bucket of JSON records kept in insertion order, plus an
+// index bucket mapping record IDs to their sequence keys.
+type table struct {
+	data  []byte
+	index []byte
+}
+
+var (
+	logsTable  = table{data: []byte("logs"), index: []byte("logs_by_id")}
+	vocabTable = table{data: []byte("vocabulary"), index: []byte("vocabulary_by_id")}
+)
+
+// BoltStore is the default Store, backed by a single bbolt database file.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens (or creates) the database at path. If the database is
+// new and a legacy data.json exists, its contents are imported once and the
+// old file is renamed so it is never imported again. Databases written with
+// an older schema are copied to a backup file and then migrated in place.
+func OpenBoltStore(path string, legacyJSON string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	s := &BoltStore{db: db}
+
+	fresh := false
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketMeta, bucketProfile, bucketPlan, logsTable.data, logsTable.index, vocabTable.data, vocabTable.index} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		fresh = tx.Bucket(bucketProfile).Get(keyProfile) == nil
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init database: %w", err)
+	}
+
+	if fresh {
+		err = s.importLegacy(legacyJSON)
+	} else {
+		err = s.migrate()
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate upgrades an existing database to currentSchemaVersion, keeping a
+// copy of the pre-migration file next to it.
+func (s *BoltStore) migrate() error {
+	state, err := s.LoadState()
+	if err != nil {
+		return fmt.Errorf("read database for migration: %w", err)
+	}
+	if state.SchemaVersion == currentSchemaVersion {
+		return nil
+	}
+	backup := fmt.Sprintf("%s.v%d.bak", s.db.Path(), state.SchemaVersion)
+	if state.SchemaVersion < currentSchemaVersion {
+		if err := s.db.View(func(tx *bolt.Tx) error {
+			return tx.CopyFile(backup, 0600)
+		}); err != nil {
+			return fmt.Errorf("back up database before migration: %w", err)
+		}
+	}
+	if _, err := migrateState(state); err != nil {
+		return err
+	}
+	return s.ReplaceState(state)
+}
+
+// importLegacy seeds a fresh database from the old data.json, or with the
+// default state when there is nothing to migrate.
+func (s *BoltStore) importLegacy(path string) error {
+	state := defaultState()
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		// data.json predates schema versioning unless it says otherwise
+		state.SchemaVersion = 0
+		// Not wrapped: a bad data.json is not a damaged database to restore
+		if err := json.Unmarshal(data, state); err != nil {
+			return fmt.Errorf("migrate %s: %v", path, err)
+		}
+		if _, err := migrateState(state); err != nil {
+			return fmt.Errorf("migrate %s: %w", path, err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("migrate %s: %w", path, err)
+	}
+
+	if err := s.ReplaceState(state); err != nil {
+		return fmt.Errorf("migrate %s: %w", path, err)
+	}
+	if data != nil {
+		return os.Rename(path, path+".migrated")
+	}
+	return nil
+}
+
+func (s *BoltStore) LoadState() (*AppState, error) {
+	state := &AppState{DailyLogs: []DailyLog{}, Vocabulary: []VocabItem{}}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if raw := tx.Bucket(bucketMeta).Get(keySchemaVersion); raw != nil {
+			state.SchemaVersion = int(binary.BigEndian.Uint64(raw))
+		}
+		if raw := tx.Bucket(bucketProfile).Get(keyProfile); raw != nil {
+			if err := json.Unmarshal(raw, &state.UserProfile); err != nil {
+				return err
+			}
+		}
+		if raw := tx.Bucket(bucketPlan).Get(keyPlan); raw != nil {
+			state.StudyPlan = &StudyPlan{}
+			if err := json.Unmarshal(raw, state.StudyPlan); err != nil {
+				return err
+			}
+		}
+		if err := logsTable.each(tx, func(raw []byte) error {
+			var log DailyLog
+			if err := json.Unmarshal(raw, &log); err != nil {
+				return err
+			}
+			state.DailyLogs = append(state.DailyLogs, log)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return vocabTable.each(tx, func(raw []byte) error {
+			var item VocabItem
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return err
+			}
+			state.Vocabulary = append(state.Vocabulary, item)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ReplaceState overwrites everything in the database with state in a single transaction.
+func (s *BoltStore) ReplaceState(state *AppState) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		version := make([]byte, 8)
+		binary.BigEndian.PutUint64(version, uint64(state.SchemaVersion))
+		if err := tx.Bucket(bucketMeta).Put(keySchemaVersion, version); err != nil {
+			return err
+		}
+		if err := putJSON(tx.Bucket(bucketProfile), keyProfile, state.UserProfile); err != nil {
+			return err
+		}
+		if err := putPlan(tx, state.StudyPlan); err != nil {
+			return err
+		}
+		if err := logsTable.clear(tx); err != nil {
+			return err
+		}
+		for _, log := range state.DailyLogs {
+			if err := logsTable.put(tx, log.ID, log); err != nil {
+				return err
+			}
+		}
+		if err := vocabTable.clear(tx); err != nil {
+			return err
+		}
+		for _, item := range state.Vocabulary {
+			if err := vocabTable.put(tx, item.ID, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) UpdateProfile(fn func(*UserProfile) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketProfile)
+		var profile UserProfile
+		if raw := b.Get(keyProfile); raw != nil {
+			if err := json.Unmarshal(raw, &profile); err != nil {
+				return err
+			}
+		}
+		if err := fn(&profile); err != nil {
+			return err
+		}
+		return putJSON(b, keyProfile, profile)
+	})
+}
+
+func (s *BoltStore) GetLog(id string) (DailyLog, error) {
+	var log DailyLog
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := logsTable.get(tx, id)
+		if raw == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(raw, &log)
+	})
+	return log, err
+}
+
+func (s *BoltStore) AddLog(log DailyLog) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return logsTable.put(tx, log.ID, log)
+	})
+}
+
+func (s *BoltStore) UpdateLog(id string, fn func(*DailyLog) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		raw := logsTable.get(tx, id)
+		if raw == nil {
+			return ErrNotFound
+		}
+		var log DailyLog
+		if err := json.Unmarshal(raw, &log); err != nil {
+			return err
+		}
+		if err := fn(&log); err != nil {
+			return err
+		}
+		log.ID = id
+		return logsTable.put(tx, id, log)
+	})
+}
+
+func (s *BoltStore) DeleteLog(id string) (bool, error) {
+	deleted := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		deleted, err = logsTable.delete(tx, id)
+		return err
+	})
+	return deleted, err
+}
+
+func (s *BoltStore) AddVocab(item VocabItem) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return vocabTable.put(tx, item.ID, item)
+	})
+}
+
+func (s *BoltStore) UpdateVocab(id string, fn func(*VocabItem) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		raw := vocabTable.get(tx, id)
+		if raw == nil {
+			return ErrNotFound
+		}
+		var item VocabItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+		item.ID = id
+		return vocabTable.put(tx, id, item)
+	})
+}
+
+func (s *BoltStore) DeleteVocab(id string) (bool, error) {
+	deleted := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		deleted, err = vocabTable.delete(tx, id)
+		return err
+	})
+	return deleted, err
+}
+
+func (s *BoltStore) SavePlan(plan *StudyPlan) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return putPlan(tx, plan)
+	})
+}
+
+func putPlan(tx *bolt.Tx, plan *StudyPlan) error {
+	if plan == nil {
+		return tx.Bucket(bucketPlan).Delete(keyPlan)
+	}
+	return putJSON(tx.Bucket(bucketPlan), keyPlan, plan)
+}
+
+// Reset wipes all user data and restores the default profile.
+func (s *BoltStore) Reset() error {
+	return s.ReplaceState(defaultState())
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func putJSON(b *bolt.Bucket, key []byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, data)
+}
+
+// put inserts or overwrites the record with the given ID. Existing records
+// keep their position; new ones are appended.
+func (t table) put(tx *bolt.Tx, id string, v interface{}) error {
+	data, index := tx.Bucket(t.data), tx.Bucket(t.index)
+	key := index.Get([]byte(id))
+	if key == nil {
+		seq, err := data.NextSequence()
+		if err != nil {
+			return err
+		}
+		key = make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		if err := index.Put([]byte(id), key); err != nil {
+			return err
+		}
+	}
+	return putJSON(data, key, v)
+}
+
+func (t table) get(tx *bolt.Tx, id string) []byte {
+	key := tx.Bucket(t.index).Get([]byte(id))
+	if key == nil {
+		return nil
+	}
+	return tx.Bucket(t.data).Get(key)
+}
+
+func (t table) delete(tx *bolt.Tx, id string) (bool, error) {
+	index := tx.Bucket(t.index)
+	key := index.Get([]byte(id))
+	if key == nil {
+		return false, nil
+	}
+	if err := tx.Bucket(t.data).Delete(key); err != nil {
+		return false, err
+	}
+	return true, index.Delete([]byte(id))
+}
+
+func (t table) each(tx *bolt.Tx, fn func(raw []byte) error) error {
+	return tx.Bucket(t.data).ForEach(func(_, v []byte) error {
+		return fn(v)
+	})
+}
+
+func (t table) clear(tx *bolt.Tx) error {
+	for _, name := range [][]byte{t.data, t.index} {
+		if err := tx.DeleteBucket(name); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
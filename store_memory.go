@@ -0,0 +1,196 @@
+package main
+
+import "sync"
+
+// MemoryStore keeps everything in process memory. The tests use it to run
+// the app's analytics against fixture states.
+type MemoryStore struct {
+	mu    sync.Mutex
+	state AppState
+}
+
+// NewMemoryStore returns a store seeded with a migrated copy of state, or
+// with the default state when state is nil.
+func NewMemoryStore(state *AppState) (*MemoryStore, error) {
+	if state == nil {
+		state = defaultState()
+	}
+	seed := cloneState(state)
+	if _, err := migrateState(&seed); err != nil {
+		return nil, err
+	}
+	return &MemoryStore{state: seed}, nil
+}
+
+func (s *MemoryStore) LoadState() (*AppState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := cloneState(&s.state)
+	return &state, nil
+}
+
+func (s *MemoryStore) ReplaceState(state *AppState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = cloneState(state)
+	return nil
+}
+
+func (s *MemoryStore) UpdateProfile(fn func(*UserProfile) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profile := s.state.UserProfile.clone()
+	if err := fn(&profile); err != nil {
+		return err
+	}
+	s.state.UserProfile = profile
+	return nil
+}
+
+func (s *MemoryStore) GetLog(id string) (DailyLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, log := range s.state.DailyLogs {
+		if log.ID == id {
+			return log, nil
+		}
+	}
+	return DailyLog{}, ErrNotFound
+}
+
+func (s *MemoryStore) AddLog(log DailyLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.DailyLogs {
+		if s.state.DailyLogs[i].ID == log.ID {
+			s.state.DailyLogs[i] = log
+			return nil
+		}
+	}
+	s.state.DailyLogs = append(s.state.DailyLogs, log)
+	return nil
+}
+
+func (s *MemoryStore) UpdateLog(id string, fn func(*DailyLog) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.DailyLogs {
+		if s.state.DailyLogs[i].ID == id {
+			log := s.state.DailyLogs[i]
+			if err := fn(&log); err != nil {
+				return err
+			}
+			log.ID = id
+			s.state.DailyLogs[i] = log
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (s *MemoryStore) DeleteLog(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.DailyLogs {
+		if s.state.DailyLogs[i].ID == id {
+			s.state.DailyLogs = append(s.state.DailyLogs[:i], s.state.DailyLogs[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *MemoryStore) AddVocab(item VocabItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.Vocabulary {
+		if s.state.Vocabulary[i].ID == item.ID {
+			s.state.Vocabulary[i] = item
+			return nil
+		}
+	}
+	s.state.Vocabulary = append(s.state.Vocabulary, item)
+	return nil
+}
+
+func (s *MemoryStore) UpdateVocab(id string, fn func(*VocabItem) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.Vocabulary {
+		if s.state.Vocabulary[i].ID == id {
+			item := s.state.Vocabulary[i]
+			item.Review.History = append([]ReviewRecord(nil), item.Review.History...)
+			item.Tags = append([]string(nil), item.Tags...)
+			if err := fn(&item); err != nil {
+				return err
+			}
+			item.ID = id
+			s.state.Vocabulary[i] = item
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (s *MemoryStore) DeleteVocab(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.Vocabulary {
+		if s.state.Vocabulary[i].ID == id {
+			s.state.Vocabulary = append(s.state.Vocabulary[:i], s.state.Vocabulary[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *MemoryStore) SavePlan(plan *StudyPlan) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.StudyPlan = plan.clone()
+	return nil
+}
+
+func (s *MemoryStore) Reset() error {
+	return s.ReplaceState(defaultState())
+}
+
+// Backup is a no-op; there is no file to snapshot.
+func (s *MemoryStore) Backup(keep int) (string, error) {
+	return "", nil
+}
+
+// BackupAttachments returns nothing, as there are no backups.
+func (s *MemoryStore) BackupAttachments() ([]string, error) {
+	return nil, nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// clone copies the slices and maps of the profile.
+func (p UserProfile) clone() UserProfile {
+	p.ReminderTimes = append([]string(nil), p.ReminderTimes...)
+	if modules := p.StudyTargets.ModuleMinutes; modules != nil {
+		p.StudyTargets.ModuleMinutes = make(map[string]int, len(modules))
+		for module, minutes := range modules {
+			p.StudyTargets.ModuleMinutes[module] = minutes
+		}
+	}
+	return p
+}
+
+// cloneState copies the slices of state so callers can't mutate the store's copy.
+func cloneState(state *AppState) AppState {
+	out := *state
+	out.UserProfile = state.UserProfile.clone()
+	out.DailyLogs = append([]DailyLog{}, state.DailyLogs...)
+	out.Vocabulary = append([]VocabItem{}, state.Vocabulary...)
+	for i := range out.Vocabulary {
+		out.Vocabulary[i].Review.History = append([]ReviewRecord(nil), out.Vocabulary[i].Review.History...)
+		out.Vocabulary[i].Tags = append([]string(nil), out.Vocabulary[i].Tags...)
+	}
+	out.StudyPlan = state.StudyPlan.clone()
+	return out
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"Engress/scoring"
+)
+
+// fixtureNow is the clock the fixture tests run at: a Saturday afternoon.
+var fixtureNow = time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+
+// fixtureSession is a session that started at the given time on the day
+// daysAgo days before fixtureNow.
+func fixtureSession(module string, minutes int, score float64, daysAgo, hour int) DailyLog {
+	started := time.Date(2026, 10, 17-daysAgo, hour, 0, 0, 0, time.UTC)
+	log := DailyLog{
+		ID:        started.Format("20060102T15") + module,
+		Module:    module,
+		Duration:  minutes,
+		Score:     score,
+		ExamType:  scoring.IELTSAcademic,
+		StartedAt: started.Format(time.RFC3339),
+	}
+	log.stampTimes(fixtureNow, time.UTC)
+	return log
+}
+
+// fixtureState holds two days of sessions in UTC: scored writing
+// yesterday, and writing and reading today.
+func fixtureState() *AppState {
+	state := defaultState()
+	state.UserProfile.Timezone = "UTC"
+	state.UserProfile.TargetScore = 7
+	state.UserProfile.StudyTargets = StudyTargets{DailyMinutes: 60, ModuleMinutes: map[string]int{"writing": 30}}
+	state.DailyLogs = []DailyLog{
+		fixtureSession("writing", 45, 6, 1, 9),
+		fixtureSession("writing", 40, 6.5, 0, 9),
+		fixtureSession("reading", 30, 0, 0, 11),
+	}
+	return state
+}
+
+// fixtureApp returns an App over a MemoryStore seeded with state.
+func fixtureApp(t *testing.T, state *AppState) *App {
+	t.Helper()
+	store, err := NewMemoryStore(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &App{state: NewStateService(store)}
+}
+
+// The store hands out copies: changing what LoadState returned, or a
+// rejected update, leaves the stored state as it was.
+func TestMemoryStoreCopies(t *testing.T) {
+	store, err := NewMemoryStore(fixtureState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, _ := store.LoadState()
+	if state.SchemaVersion != currentSchemaVersion {
+		t.Errorf("seed not migrated: schema %d, want %d", state.SchemaVersion, currentSchemaVersion)
+	}
+	state.DailyLogs[0].Duration = 999
+	state.UserProfile.ReminderTimes[0] = "06:00"
+
+	err = store.UpdateLog(state.DailyLogs[1].ID, func(log *DailyLog) error {
+		log.Duration = 999
+		return &ValidationError{Field: "duration", Message: "rejected"}
+	})
+	if err == nil {
+		t.Fatal("error not returned")
+	}
+
+	again, _ := store.LoadState()
+	if again.DailyLogs[0].Duration != 45 || again.DailyLogs[1].Duration != 40 || again.UserProfile.ReminderTimes[0] != "10:00" {
+		t.Errorf("changes leaked into the store: %+v", again)
+	}
+	if _, err := store.GetLog("missing"); err != ErrNotFound {
+		t.Errorf("GetLog of a missing ID: got %v, want ErrNotFound", err)
+	}
+}
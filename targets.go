@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultDailyMinutes is the daily target until the user sets their own.
+const defaultDailyMinutes = 120
+
+// StudyTargets is how much the user commits to study. Zero values fall back
+// to defaults: DailyMinutes to defaultDailyMinutes, WeeklyMinutes to seven
+// days of the daily target.
+type StudyTargets struct {
+	DailyMinutes  int            `json:"daily_minutes"`
+	WeeklyMinutes int            `json:"weekly_minutes"`
+	ModuleMinutes map[string]int `json:"module_minutes"` // Optional daily minutes per module, e.g. {"writing": 40}
+	// Taper scales the targets with the time left before TestDate: lighter
+	// far out, heavier in the final weeks, and easing off just before the test.
+	Taper bool `json:"taper"`
+}
+
+// taperFactor is the multiplier Taper applies daysLeft days before the test.
+func taperFactor(daysLeft int) float64 {
+	switch {
+	case daysLeft < 0:
+		return 1 // Test is over
+	case daysLeft <= 3:
+		return 0.5
+	case daysLeft <= 14:
+		return 1.25
+	case daysLeft <= 60:
+		return 1
+	default:
+		return 0.75
+	}
+}
+
+// daysUntilTest returns the whole days from today to TestDate, and false if
+// TestDate is unset or malformed.
+func (p UserProfile) daysUntilTest(now time.Time) (int, bool) {
+	loc := now.Location()
+	test, err := time.ParseInLocation(dayLayout, p.TestDate, loc)
+	if err != nil {
+		return 0, false
+	}
+	today, _ := time.ParseInLocation(dayLayout, now.Format(dayLayout), loc)
+	return int(test.Sub(today).Hours()/24 + 0.5), true
+}
+
+// ModuleProgress is today's minutes against one module's target.
+type ModuleProgress struct {
+	Module string `json:"module"`
+	Target int    `json:"target"`
+	Logged int    `json:"logged"`
+}
+
+// TargetProgress is how today and this week (Monday to Sunday) measure up
+// against the profile's study targets, after tapering.
+type TargetProgress struct {
+	Day           string           `json:"day"`
+	DailyTarget   int              `json:"daily_target"`
+	DailyLogged   int              `json:"daily_logged"`
+	WeeklyTarget  int              `json:"weekly_target"`
+	WeeklyLogged  int              `json:"weekly_logged"`
+	Modules       []ModuleProgress `json:"modules"`
+	TaperFactor   float64          `json:"taper_factor"` // 1 unless Taper is on
+	DaysUntilTest int              `json:"days_until_test"`
+}
+
+// DailyMet reports whether today's total and every module target are met.
+func (t TargetProgress) DailyMet() bool {
+	if t.DailyLogged < t.DailyTarget {
+		return false
+	}
+	for _, m := range t.Modules {
+		if m.Logged < m.Target {
+			return false
+		}
+	}
+	return true
+}
+
+func scaleMinutes(minutes int, factor float64) int {
+	return int(float64(minutes)*factor + 0.5)
+}
+
+func targetProgress(logs []DailyLog, profile UserProfile, now time.Time) TargetProgress {
+	loc := profile.location()
+	now = now.In(loc)
+	targets := profile.StudyTargets
+
+	p := TargetProgress{
+		Day:         now.Format(dayLayout),
+		Modules:     []ModuleProgress{},
+		TaperFactor: 1,
+	}
+	if days, ok := profile.daysUntilTest(now); ok {
+		p.DaysUntilTest = days
+		if targets.Taper {
+			p.TaperFactor = taperFactor(days)
+		}
+	}
+
+	daily := targets.DailyMinutes
+	if daily <= 0 {
+		daily = defaultDailyMinutes
+	}
+	weekly := targets.WeeklyMinutes
+	if weekly <= 0 {
+		weekly = 7 * daily
+	}
+	p.DailyTarget = scaleMinutes(daily, p.TaperFactor)
+	p.WeeklyTarget = scaleMinutes(weekly, p.TaperFactor)
+
+	modules := make([]string, 0, len(targets.ModuleMinutes))
+	for module, minutes := range targets.ModuleMinutes {
+		if minutes > 0 {
+			modules = append(modules, module)
+		}
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		p.Modules = append(p.Modules, ModuleProgress{
+			Module: module,
+			Target: scaleMinutes(targets.ModuleMinutes[module], p.TaperFactor),
+		})
+	}
+
+	weekday := (int(now.Weekday()) + 6) % 7 // Days since Monday
+	weekStart := now.AddDate(0, 0, -weekday).Format(dayLayout)
+	for _, log := range logs {
+		day := log.day(loc)
+		if day >= weekStart && day <= p.Day {
+			p.WeeklyLogged += log.Duration
+		}
+		if day != p.Day {
+			continue
+		}
+		p.DailyLogged += log.Duration
+		for i := range p.Modules {
+			if strings.EqualFold(p.Modules[i].Module, log.Module) {
+				p.Modules[i].Logged += log.Duration
+			}
+		}
+	}
+	return p
+}
+
+// GetTargetProgress returns today's and this week's minutes against the study targets.
+func (a *App) GetTargetProgress() (TargetProgress, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return TargetProgress{}, err
+	}
+	return targetProgress(state.DailyLogs, state.UserProfile, time.Now()), nil
+}
+
+// UpdateStudyTargets replaces the daily, weekly and per-module study targets.
+func (a *App) UpdateStudyTargets(targets StudyTargets) error {
+	const maxDaily = 24 * 60
+	if targets.DailyMinutes < 0 || targets.DailyMinutes > maxDaily {
+		return &ValidationError{Field: "daily_minutes", Message: fmt.Sprintf("must be between 0 and %d", maxDaily)}
+	}
+	if targets.WeeklyMinutes < 0 || targets.WeeklyMinutes > 7*maxDaily {
+		return &ValidationError{Field: "weekly_minutes", Message: fmt.Sprintf("must be between 0 and %d", 7*maxDaily)}
+	}
+	modules := make(map[string]int, len(targets.ModuleMinutes))
+	for module, minutes := range targets.ModuleMinutes {
+		if err := validateModule(module); err != nil {
+			return &ValidationError{Field: "module_minutes", Message: err.(*ValidationError).Message}
+		}
+		if minutes < 0 || minutes > maxDaily {
+			return &ValidationError{Field: "module_minutes", Message: fmt.Sprintf("%s must be between 0 and %d", module, maxDaily)}
+		}
+		if minutes > 0 {
+			modules[strings.ToLower(module)] = minutes
+		}
+	}
+	targets.ModuleMinutes = modules
+	return a.state.UpdateProfile(func(p *UserProfile) error {
+		p.StudyTargets = targets
+		return nil
+	})
+}
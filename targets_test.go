@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTargetProgress(t *testing.T) {
+	state := fixtureState()
+	progress := targetProgress(state.DailyLogs, state.UserProfile, fixtureNow)
+	if progress.Day != "2026-10-17" || progress.DailyLogged != 70 || progress.DailyTarget != 60 {
+		t.Errorf("got %d of %d minutes on %s, want 70 of 60 on 2026-10-17", progress.DailyLogged, progress.DailyTarget, progress.Day)
+	}
+	// Monday to Saturday
+	if progress.WeeklyLogged != 115 {
+		t.Errorf("got %d minutes this week, want 115", progress.WeeklyLogged)
+	}
+	if len(progress.Modules) != 1 || progress.Modules[0].Module != "writing" || progress.Modules[0].Logged != 40 {
+		t.Errorf("got module progress %+v, want writing at 40", progress.Modules)
+	}
+	if !progress.DailyMet() {
+		t.Error("targets reported as not met")
+	}
+}
+
+// A rejected profile update leaves the module targets as they were.
+func TestUpdateProfileCopiesModuleTargets(t *testing.T) {
+	a := fixtureApp(t, fixtureState())
+	err := a.state.UpdateProfile(func(p *UserProfile) error {
+		p.StudyTargets.ModuleMinutes["writing"] = 90
+		return errors.New("rejected")
+	})
+	if err == nil {
+		t.Fatal("error not returned")
+	}
+	state, _ := a.state.LoadState()
+	if got := state.UserProfile.StudyTargets.ModuleMinutes["writing"]; got != 30 {
+		t.Errorf("rejected update leaked into the store: writing target %d, want 30", got)
+	}
+}
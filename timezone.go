@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const dayLayout = "2006-01-02"
+
+// systemTimezone returns the IANA name of the machine's timezone, or "" when
+// it can't be determined, as on Windows (time.Local is just "Local").
+func systemTimezone() string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		if _, err := time.LoadLocation(tz); err == nil {
+			return tz
+		}
+	}
+	if target, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+		if i := strings.Index(target, "zoneinfo/"); i >= 0 {
+			name := target[i+len("zoneinfo/"):]
+			if _, err := time.LoadLocation(name); err == nil {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// location returns the profile's timezone, or the system one if it is unset
+// or invalid. time.Local is right even where systemTimezone has no name for
+// it: Go reads the Windows zone from the registry.
+func (p UserProfile) location() *time.Location {
+	if p.Timezone != "" {
+		if loc, err := time.LoadLocation(p.Timezone); err == nil {
+			return loc
+		}
+	}
+	if tz := systemTimezone(); tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// sessionStart returns when the session began. Logs without a timestamp
+// (which the v2 migration should have backfilled) fall back to Date at noon.
+func (log DailyLog) sessionStart(loc *time.Location) time.Time {
+	if t, err := time.Parse(time.RFC3339, log.StartedAt); err == nil {
+		return t.In(loc)
+	}
+	t, err := time.ParseInLocation(dayLayout, log.Date, loc)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.Add(12 * time.Hour)
+}
+
+// day is the calendar day ("2006-01-02") in loc that the session counts
+// towards: the day it started, so a session across midnight is not split.
+func (log DailyLog) day(loc *time.Location) string {
+	if log.StartedAt == "" {
+		return log.Date
+	}
+	return log.sessionStart(loc).Format(dayLayout)
+}
+
+// stampTimes fills in whichever of StartedAt/EndedAt is missing from the
+// duration, defaulting the end to now, and derives Date and Time from them.
+func (log *DailyLog) stampTimes(now time.Time, loc *time.Location) {
+	started, _ := time.Parse(time.RFC3339, log.StartedAt)
+	ended, _ := time.Parse(time.RFC3339, log.EndedAt)
+	length := time.Duration(log.Duration) * time.Minute
+	switch {
+	case ended.IsZero() && started.IsZero():
+		ended = now
+		started = now.Add(-length)
+	case ended.IsZero():
+		ended = started.Add(length)
+	case started.IsZero():
+		started = ended.Add(-length)
+	}
+	log.StartedAt = started.In(loc).Format(time.RFC3339)
+	log.EndedAt = ended.In(loc).Format(time.RFC3339)
+	log.Date = started.In(loc).Format(dayLayout)
+	log.Time = ended.In(loc).Format("15:04")
+}
+
+// UpdateTimezone sets the IANA timezone used to bucket sessions into days.
+func (a *App) UpdateTimezone(tz string) error {
+	if _, err := time.LoadLocation(tz); err != nil || tz == "" {
+		return &ValidationError{Field: "timezone", Message: fmt.Sprintf("unknown timezone %q", tz)}
+	}
+	return a.state.UpdateProfile(func(p *UserProfile) error {
+		p.Timezone = tz
+		return nil
+	})
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Vocabulary export formats.
+const (
+	VocabCSV  = "csv"
+	VocabAnki = "anki" // Anki "Notes in Plain Text" file
+)
+
+// previewRows is how many rows the import preview shows.
+const previewRows = 5
+
+// VocabFieldMapping says which column feeds each VocabItem field. -1 leaves
+// the field empty.
+type VocabFieldMapping struct {
+	Word      int `json:"word"`
+	Def       int `json:"def"`
+	Sentences int `json:"sentences"`
+	Topic     int `json:"topic"`
+	Tags      int `json:"tags"` // Split on commas, semicolons and spaces
+}
+
+// VocabImportPreview is a parsed import file for the user to map columns.
+type VocabImportPreview struct {
+	Path    string            `json:"path"`
+	Columns []string          `json:"columns"`
+	Rows    [][]string        `json:"rows"` // The first few rows
+	Total   int               `json:"total"`
+	Mapping VocabFieldMapping `json:"mapping"` // Best guess from the column names
+}
+
+// VocabImportResult reports what an import did.
+type VocabImportResult struct {
+	Imported   int      `json:"imported"`
+	Duplicates []string `json:"duplicates"` // Words already in the list, or repeated in the file
+	Skipped    int      `json:"skipped"`    // Rows with no word
+}
+
+// vocabSheet is an import file read into columns and rows.
+type vocabSheet struct {
+	columns []string
+	rows    [][]string
+}
+
+// readVocabSheet reads a CSV, TSV, Anki plain text or .apkg file.
+func readVocabSheet(path string) (vocabSheet, error) {
+	if strings.EqualFold(filepath.Ext(path), ".apkg") {
+		notes, err := readApkg(path)
+		if err != nil {
+			return vocabSheet{}, err
+		}
+		var sheet vocabSheet
+		for _, n := range notes {
+			row := make([]string, len(n.Fields))
+			for i, f := range n.Fields {
+				row[i] = htmlToText(f)
+			}
+			if sheet.columns == nil && len(n.Names) == len(n.Fields) {
+				sheet.columns = n.Names
+			}
+			sheet.rows = append(sheet.rows, row)
+		}
+		return sheet.withColumns(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return vocabSheet{}, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	return parseDelimited(data, strings.ToLower(filepath.Ext(path)))
+}
+
+// parseDelimited reads CSV and TSV files, and Anki plain text exports with
+// their "#key:value" header lines.
+func parseDelimited(data []byte, ext string) (vocabSheet, error) {
+	var sheet vocabSheet
+	isHTML := ext == ".txt" // Anki exports HTML unless it says otherwise
+	sep := rune(0)
+	switch ext {
+	case ".tsv", ".txt":
+		sep = '\t'
+	case ".csv":
+		sep = ','
+	}
+
+	// Anki header lines
+	for bytes.HasPrefix(data, []byte("#")) {
+		line := data
+		rest := []byte(nil)
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, rest = data[:i], data[i+1:]
+		}
+		key, value, ok := strings.Cut(strings.TrimSpace(string(line)), ":")
+		if !ok {
+			break
+		}
+		switch key {
+		case "#separator":
+			sep = map[string]rune{"tab": '\t', "comma": ',', "semicolon": ';', "pipe": '|', "space": ' ', "colon": ':'}[strings.ToLower(value)]
+		case "#html":
+			isHTML = value == "true"
+		case "#columns":
+			sheet.columns = strings.Split(value, "\t")
+		}
+		data = rest
+	}
+	if sep == 0 {
+		sep = sniffSeparator(data)
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = sep
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return vocabSheet{}, &ValidationError{Field: "file", Message: err.Error()}
+		}
+		if isHTML {
+			for i, f := range record {
+				record[i] = htmlToText(f)
+			}
+		}
+		sheet.rows = append(sheet.rows, record)
+	}
+
+	// A first row naming a known field is a header
+	if sheet.columns == nil && len(sheet.rows) > 0 {
+		if m := guessMapping(sheet.rows[0]); m.Word >= 0 {
+			sheet.columns, sheet.rows = sheet.rows[0], sheet.rows[1:]
+		}
+	}
+	return sheet.withColumns(), nil
+}
+
+// sniffSeparator picks tab, semicolon or comma by which is most common in
+// the first line.
+func sniffSeparator(data []byte) rune {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	best, bestCount := ',', bytes.Count(line, []byte(","))
+	for _, sep := range []rune{'\t', ';'} {
+		if n := bytes.Count(line, []byte(string(sep))); n > bestCount {
+			best, bestCount = sep, n
+		}
+	}
+	return best
+}
+
+// withColumns names any unnamed columns "Column N", up to the widest row.
+func (s vocabSheet) withColumns() vocabSheet {
+	width := len(s.columns)
+	for _, row := range s.rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	for i := len(s.columns); i < width; i++ {
+		s.columns = append(s.columns, fmt.Sprintf("Column %d", i+1))
+	}
+	return s
+}
+
+var (
+	htmlBreak = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>|</li>`)
+	htmlTag   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// htmlToText turns an Anki field into plain text, keeping line breaks.
+func htmlToText(s string) string {
+	s = htmlBreak.ReplaceAllString(s, "\n")
+	s = htmlTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, " ", " ")
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, l := range lines {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// guessMapping matches column names to VocabItem fields.
+func guessMapping(columns []string) VocabFieldMapping {
+	m := VocabFieldMapping{Word: -1, Def: -1, Sentences: -1, Topic: -1, Tags: -1}
+	names := map[string]*int{
+		"word": &m.Word, "term": &m.Word, "front": &m.Word, "expression": &m.Word, "vocabulary": &m.Word,
+		"def": &m.Def, "definition": &m.Def, "meaning": &m.Def, "back": &m.Def,
+		"sentences": &m.Sentences, "sentence": &m.Sentences, "example": &m.Sentences, "examples": &m.Sentences,
+		"topic": &m.Topic, "tags": &m.Tags, "tag": &m.Tags,
+	}
+	for i, c := range columns {
+		if field, ok := names[strings.ToLower(strings.TrimSpace(c))]; ok && *field < 0 {
+			*field = i
+		}
+	}
+	return m
+}
+
+// defaultMapping uses the column names if it can, else the first columns in order.
+func (s vocabSheet) defaultMapping() VocabFieldMapping {
+	m := guessMapping(s.columns)
+	if m.Word >= 0 {
+		return m
+	}
+	m = VocabFieldMapping{Word: -1, Def: -1, Sentences: -1, Topic: -1, Tags: -1}
+	for i, field := range []*int{&m.Word, &m.Def, &m.Sentences} {
+		if i < len(s.columns) {
+			*field = i
+		}
+	}
+	return m
+}
+
+// importVocab turns sheet rows into new items, skipping words already in
+// existing or earlier in the sheet, in any case or inflection.
+func importVocab(sheet vocabSheet, m VocabFieldMapping, existing []VocabItem, d *Dictionary, now time.Time) ([]VocabItem, VocabImportResult) {
+	cell := func(row []string, i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+	seen := d.newWordIndex()
+	for _, item := range existing {
+		seen.add(item.Word)
+	}
+
+	result := VocabImportResult{Duplicates: []string{}}
+	var items []VocabItem
+	for _, row := range sheet.rows {
+		word := cell(row, m.Word)
+		if word == "" {
+			result.Skipped++
+			continue
+		}
+		if seen.has(word) {
+			result.Duplicates = append(result.Duplicates, word)
+			continue
+		}
+		seen.add(word)
+		items = append(items, VocabItem{
+			ID:        fmt.Sprintf("%d", now.UnixNano()+int64(len(items))),
+			Word:      word,
+			Def:       cell(row, m.Def),
+			Sentences: cell(row, m.Sentences),
+			Topic:     normalizeTopic(cell(row, m.Topic)),
+			Tags:      normalizeTags([]string{cell(row, m.Tags)}),
+			DateAdded: now.Format(dayLayout),
+			Time:      now.Format("15:04"),
+		})
+	}
+	result.Imported = len(items)
+	return items, result
+}
+
+// PreviewVocabularyImport asks for a CSV, TSV, Anki .txt or .apkg file and
+// returns its columns, first rows and a suggested field mapping. It returns
+// nil if the dialog was cancelled.
+func (a *App) PreviewVocabularyImport() (*VocabImportPreview, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import Vocabulary",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Vocabulary Files (*.csv, *.tsv, *.txt, *.apkg)", Pattern: "*.csv;*.tsv;*.txt;*.apkg"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+	sheet, err := readVocabSheet(path)
+	if err != nil {
+		return nil, err
+	}
+	preview := &VocabImportPreview{
+		Path:    path,
+		Columns: sheet.columns,
+		Rows:    sheet.rows,
+		Total:   len(sheet.rows),
+		Mapping: sheet.defaultMapping(),
+	}
+	if len(preview.Rows) > previewRows {
+		preview.Rows = preview.Rows[:previewRows]
+	}
+	return preview, nil
+}
+
+// ImportVocabulary adds the rows of a previewed file using mapping.
+// Words already in the list are skipped and reported as duplicates.
+func (a *App) ImportVocabulary(path string, mapping VocabFieldMapping) (VocabImportResult, error) {
+	if mapping.Word < 0 {
+		return VocabImportResult{}, &ValidationError{Field: "word", Message: "choose the column holding the word"}
+	}
+	sheet, err := readVocabSheet(path)
+	if err != nil {
+		return VocabImportResult{}, err
+	}
+	var result VocabImportResult
+	err = a.state.Do(func(store Store) error {
+		state, err := store.LoadState()
+		if err != nil {
+			return err
+		}
+		var items []VocabItem
+		items, result = importVocab(sheet, mapping, state.Vocabulary, a.dictionary, time.Now().In(state.UserProfile.location()))
+		for _, item := range items {
+			if err := store.AddVocab(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// writeVocabCSV writes a header and one row per item; Sentences keeps its
+// newlines inside a quoted field.
+func writeVocabCSV(w io.Writer, items []VocabItem) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"word", "definition", "sentences", "topic", "tags"})
+	for _, item := range items {
+		cw.Write([]string{item.Word, item.Def, item.Sentences, item.Topic, strings.Join(item.Tags, ",")})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeVocabAnki writes an Anki "Notes in Plain Text" file for the Basic
+// note type: the word on the front, the definition and example sentences
+// on the back. The topic and tags become Anki tags.
+func writeVocabAnki(w io.Writer, items []VocabItem) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "#separator:tab\n#html:true\n#notetype:Basic\n#deck:Engress Vocabulary\n#columns:Front\tBack\tTags\n#tags column:3\n")
+	field := func(s string) string {
+		s = html.EscapeString(strings.TrimSpace(s))
+		s = strings.ReplaceAll(s, "\t", " ")
+		return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "<br>")
+	}
+	for _, item := range items {
+		back := field(item.Def)
+		if sentences := field(item.Sentences); sentences != "" {
+			if back != "" {
+				back += "<br><br>"
+			}
+			back += "<i>" + sentences + "</i>"
+		}
+		var tags []string
+		if item.Topic != "" {
+			tags = append(tags, strings.ReplaceAll(item.Topic, " ", "_"))
+		}
+		tags = append(tags, item.Tags...)
+		fmt.Fprintf(bw, "%s\t%s\t%s\n", field(item.Word), back, field(strings.Join(tags, " ")))
+	}
+	return bw.Flush()
+}
+
+// ExportVocabulary saves the vocabulary list as "csv" or as an "anki"
+// plain text deck to a file chosen in a save dialog. It returns the saved
+// path, or "" if the dialog was cancelled.
+func (a *App) ExportVocabulary(format string) (string, error) {
+	var write func(io.Writer, []VocabItem) error
+	var filter runtime.FileFilter
+	var filename string
+	switch format {
+	case VocabCSV:
+		write, filename = writeVocabCSV, "engress-vocabulary.csv"
+		filter = runtime.FileFilter{DisplayName: "CSV Files (*.csv)", Pattern: "*.csv"}
+	case VocabAnki:
+		write, filename = writeVocabAnki, "engress-vocabulary-anki.txt"
+		filter = runtime.FileFilter{DisplayName: "Anki Text Files (*.txt)", Pattern: "*.txt"}
+	default:
+		return "", &ValidationError{Field: "format", Message: fmt.Sprintf("unknown vocabulary format %q", format)}
+	}
+
+	state, err := a.state.LoadState()
+	if err != nil {
+		return "", err
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: filename,
+		Title:           "Export Vocabulary",
+		Filters:         []runtime.FileFilter{filter},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	err = writeFileAtomic(path, 0644, func(w io.Writer) error {
+		return write(w, state.Vocabulary)
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
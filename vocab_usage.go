@@ -0,0 +1,495 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// usageWeeks is how many weeks of essay usage GetVocabularyUsage returns.
+	usageWeeks = 12
+	// maxCandidates caps the vocabulary candidates suggested at once.
+	maxCandidates = 30
+	// minAdvancedLength is the length from which an uncommon word counts as
+	// advanced even if neither the dictionary nor the AWL has it.
+	minAdvancedLength = 8
+)
+
+var (
+	urlPattern   = regexp.MustCompile(`\S*(://|data:)\S*`)
+	legacyHeader = regexp.MustCompile(`(?m)^\s*((TITLE|PREMISE|UNFINISHED TASK [12]):.*|---\s*)$`)
+	sentenceEnd  = regexp.MustCompile(`[.!?]+["”)]?\s+|\n+`)
+	wordPattern  = regexp.MustCompile(`[A-Za-z]+(?:['’-][A-Za-z]+)*`)
+)
+
+// loadCommonWords reads wordlists/common.txt, the words never suggested.
+var loadCommonWords = sync.OnceValue(func() map[string]bool {
+	words := make(map[string]bool)
+	f, err := embeddedWordLists.Open("wordlists/common.txt")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			words[dictionaryKey(line)] = true
+		}
+	}
+	return words
+})
+
+// UsedWord is a saved word found in a text.
+type UsedWord struct {
+	ID    string `json:"id"`
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// VocabCandidate is an advanced word from reading content that is not in
+// the vocabulary yet.
+type VocabCandidate struct {
+	Word    string `json:"word"` // The base form when the dictionary knows it
+	Count   int    `json:"count"`
+	Def     string `json:"def"`     // From the offline dictionary; empty if not found
+	Sublist int    `json:"sublist"` // Academic Word List sublist; 0 if not on it
+	Example string `json:"example"` // The first sentence it appeared in
+	LogID   string `json:"log_id"`  // The log of that sentence
+}
+
+// LogVocabulary is the vocabulary analysis of one session log.
+type LogVocabulary struct {
+	LogID      string           `json:"log_id"`
+	Words      int              `json:"words"`      // Words in the analysed text
+	Used       []UsedWord       `json:"used"`       // Saved words in the text, most first
+	Candidates []VocabCandidate `json:"candidates"` // Advanced words not saved yet
+}
+
+// UsagePoint is one week of saved-word usage in essays.
+type UsagePoint struct {
+	Week   string `json:"week"` // Monday, "2006-01-02"
+	Essays int    `json:"essays"`
+	Words  int    `json:"words"` // Words written in essays
+	Used   int    `json:"used"`  // Distinct saved words used in essays
+}
+
+// WordUsage is how often a saved word was used in essays.
+type WordUsage struct {
+	ID       string `json:"id"`
+	Word     string `json:"word"`
+	Count    int    `json:"count"`
+	LastUsed string `json:"last_used"` // "2006-01-02"
+}
+
+// VocabularyUsage reports how much of the vocabulary is actively used in
+// essays. A use only counts on or after the day the word was saved.
+type VocabularyUsage struct {
+	Essays     int              `json:"essays"`
+	Saved      int              `json:"saved"`
+	Used       int              `json:"used"`    // Saved words used in at least one essay
+	Percent    float64          `json:"percent"` // Used as a percentage of Saved
+	Weekly     []UsagePoint     `json:"weekly"`  // The last usageWeeks weeks, oldest first
+	Words      []WordUsage      `json:"words"`   // Most used first
+	Candidates []VocabCandidate `json:"candidates"`
+}
+
+// logText returns the text of a log written or read by the user: essays
+// without their prompts, speaking notes, or reading and listening content.
+// Vocabulary logs only list the words saved, so they have no text.
+func logText(log DailyLog) string {
+	content := log.Content
+	switch strings.ToLower(log.Module) {
+	case "vocabulary":
+		return ""
+	case "writing":
+		var w struct {
+			Type  string `json:"type"`
+			Task1 struct {
+				Text string `json:"text"`
+			} `json:"task1"`
+			Task2 struct {
+				Text string `json:"text"`
+			} `json:"task2"`
+		}
+		if json.Unmarshal([]byte(content), &w) == nil && w.Type == "writing_v2" {
+			content = w.Task1.Text + "\n" + w.Task2.Text
+		} else {
+			content = legacyHeader.ReplaceAllString(content, "")
+		}
+	case "speaking":
+		var s struct {
+			Notes string `json:"notes"`
+		}
+		if json.Unmarshal([]byte(content), &s) == nil {
+			content = s.Notes
+		}
+	}
+	return htmlToText(urlPattern.ReplaceAllString(content, "\n")) // A link ends a sentence
+}
+
+// isEssay reports whether a log's text is the user's own writing.
+func isEssay(log DailyLog) bool {
+	return strings.EqualFold(log.Module, "writing")
+}
+
+// isReading reports whether a log's text is a passage to learn words from.
+func isReading(log DailyLog) bool {
+	return strings.EqualFold(log.Module, "reading") || strings.EqualFold(log.Module, "listening")
+}
+
+// token is a word of a text, lowercased.
+type token struct {
+	word     string
+	sentence int
+	lower    bool // Written in lowercase, so not a name
+}
+
+// tokenize splits text into sentences and their words. Possessive "'s" is
+// dropped.
+func tokenize(text string) ([]token, []string) {
+	var tokens []token
+	var sentences []string
+	for _, s := range sentenceEnd.Split(text, -1) {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		for _, w := range wordPattern.FindAllString(s, -1) {
+			w = strings.ReplaceAll(w, "’", "'")
+			w = strings.TrimSuffix(strings.TrimSuffix(w, "'s"), "'S")
+			tokens = append(tokens, token{word: strings.ToLower(w), sentence: len(sentences), lower: w == strings.ToLower(w)})
+		}
+		sentences = append(sentences, s)
+	}
+	return tokens, sentences
+}
+
+// textAnalyzer matches text against the vocabulary and word lists, caching
+// what it learns about each word.
+type textAnalyzer struct {
+	dict    *Dictionary
+	phrases [][]string       // The words of each vocabulary item
+	byFirst map[string][]int // Vocabulary items by the canonical form of their first word
+
+	forms   map[string][]string
+	canon   map[string]string
+	sublist map[string]int
+}
+
+func newTextAnalyzer(d *Dictionary, vocab []VocabItem) *textAnalyzer {
+	a := &textAnalyzer{
+		dict:    d,
+		byFirst: make(map[string][]int),
+		forms:   make(map[string][]string),
+		canon:   make(map[string]string),
+		sublist: make(map[string]int),
+	}
+	for i, item := range vocab {
+		var words []string
+		for _, w := range wordPattern.FindAllString(item.Word, -1) {
+			words = append(words, strings.ToLower(w))
+		}
+		a.phrases = append(a.phrases, words)
+		if len(words) > 0 {
+			a.byFirst[a.canonical(words[0])] = append(a.byFirst[a.canonical(words[0])], i)
+		}
+	}
+	return a
+}
+
+// stems caches Dictionary.stems: word and the words it exactly inflects.
+func (a *textAnalyzer) stems(word string) []string {
+	forms, ok := a.forms[word]
+	if !ok {
+		forms = a.dict.stems(word)
+		a.forms[word] = forms
+	}
+	return forms
+}
+
+// canonical returns the dictionary lemma of word, or word itself if the
+// dictionary does not know it.
+func (a *textAnalyzer) canonical(word string) string {
+	c, ok := a.canon[word]
+	if !ok {
+		c = word
+		if lemmas := a.dict.lemmas(word); len(lemmas) > 0 {
+			c = lemmas[0]
+		}
+		a.canon[word] = c
+	}
+	return c
+}
+
+// matches reports whether a word of a text is a saved word or one of its
+// inflections, by the saved word's exact inflection table, so "letter"
+// does not count for a saved "let".
+func (a *textAnalyzer) matches(word, saved string) bool {
+	return word == saved || a.canonical(word) == a.canonical(saved) || contains(a.stems(word), saved)
+}
+
+// used counts the vocabulary items in tokens, by index.
+func (a *textAnalyzer) used(tokens []token) map[int]int {
+	counts := make(map[int]int)
+	for i, t := range tokens {
+		seen := make(map[int]bool)
+		for _, form := range append([]string{a.canonical(t.word)}, a.stems(t.word)...) {
+			for _, idx := range a.byFirst[form] {
+				if seen[idx] {
+					continue
+				}
+				seen[idx] = true
+				phrase := a.phrases[idx]
+				if i+len(phrase) > len(tokens) {
+					continue
+				}
+				ok := true
+				for j, w := range phrase {
+					if !a.matches(tokens[i+j].word, w) {
+						ok = false
+						break
+					}
+				}
+				if ok {
+					counts[idx]++
+				}
+			}
+		}
+	}
+	return counts
+}
+
+// isCommon reports whether word or any of its stems is common.
+func (a *textAnalyzer) isCommon(word string) bool {
+	common := loadCommonWords()
+	for _, form := range a.stems(word) {
+		if common[form] {
+			return true
+		}
+	}
+	return false
+}
+
+// awlSublist returns the AWL sublist of word's family, or 0.
+func (a *textAnalyzer) awlSublist(word string) int {
+	n, ok := a.sublist[word]
+	if ok {
+		return n
+	}
+	families := a.dict.families(word)
+	for _, l := range loadWordLists() {
+		if l.Kind != ListAWL {
+			continue
+		}
+		for _, w := range l.Words {
+			if families[w] {
+				fmt.Sscanf(l.ID, "awl-%d", &n)
+				break
+			}
+		}
+		if n > 0 {
+			break
+		}
+	}
+	a.sublist[word] = n
+	return n
+}
+
+// candidates returns the advanced words of tokens that are not saved yet,
+// keyed by base form. A word is advanced when it is not common and either
+// on the AWL, in the dictionary or at least minAdvancedLength letters long.
+// Words only ever capitalized are taken for names and skipped.
+func (a *textAnalyzer) candidates(tokens []token, sentences []string, logID string, into map[string]*VocabCandidate) {
+	lower := make(map[string]bool)
+	for _, t := range tokens {
+		lower[t.word] = lower[t.word] || t.lower
+	}
+	for _, t := range tokens {
+		if !lower[t.word] || len(t.word) < 4 || strings.ContainsAny(t.word, "'-") || a.isCommon(t.word) {
+			continue
+		}
+		word := a.canonical(t.word)
+		if c, ok := into[word]; ok {
+			c.Count++
+			continue
+		}
+		saved := false
+		for _, phrase := range a.phrases {
+			if len(phrase) == 1 && a.matches(t.word, phrase[0]) {
+				saved = true
+				break
+			}
+		}
+		if saved {
+			continue
+		}
+		c := &VocabCandidate{Word: strings.ReplaceAll(word, "_", " "), Count: 1, Sublist: a.awlSublist(word), Example: sentences[t.sentence], LogID: logID}
+		if r := a.dict.Lookup(word); r.Found {
+			c.Def = r.Def
+		}
+		if c.Sublist == 0 && c.Def == "" && len(word) < minAdvancedLength {
+			continue
+		}
+		into[word] = c
+	}
+}
+
+// sortedCandidates orders candidates by count, then AWL words by sublist.
+func sortedCandidates(found map[string]*VocabCandidate) []VocabCandidate {
+	out := make([]VocabCandidate, 0, len(found))
+	for _, c := range found {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		si, sj := out[i].Sublist, out[j].Sublist
+		if (si == 0) != (sj == 0) {
+			return si != 0
+		}
+		if si != sj {
+			return si < sj
+		}
+		return out[i].Word < out[j].Word
+	})
+	if len(out) > maxCandidates {
+		out = out[:maxCandidates]
+	}
+	return out
+}
+
+func analyzeLogVocabulary(log DailyLog, vocab []VocabItem, d *Dictionary) LogVocabulary {
+	a := newTextAnalyzer(d, vocab)
+	tokens, sentences := tokenize(logText(log))
+	r := LogVocabulary{LogID: log.ID, Words: len(tokens), Used: []UsedWord{}, Candidates: []VocabCandidate{}}
+	for idx, n := range a.used(tokens) {
+		r.Used = append(r.Used, UsedWord{ID: vocab[idx].ID, Word: vocab[idx].Word, Count: n})
+	}
+	sort.Slice(r.Used, func(i, j int) bool {
+		if r.Used[i].Count != r.Used[j].Count {
+			return r.Used[i].Count > r.Used[j].Count
+		}
+		return r.Used[i].Word < r.Used[j].Word
+	})
+	if isReading(log) {
+		found := make(map[string]*VocabCandidate)
+		a.candidates(tokens, sentences, log.ID, found)
+		r.Candidates = sortedCandidates(found)
+	}
+	return r
+}
+
+func vocabularyUsage(state *AppState, d *Dictionary, now time.Time) VocabularyUsage {
+	loc := state.UserProfile.location()
+	now = now.In(loc)
+	vocab := state.Vocabulary
+	a := newTextAnalyzer(d, vocab)
+	u := VocabularyUsage{Saved: len(vocab), Weekly: []UsagePoint{}, Words: []WordUsage{}}
+
+	thisWeek := now.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+	weeks := make(map[string]int, usageWeeks)
+	for i := 0; i < usageWeeks; i++ {
+		week := thisWeek.AddDate(0, 0, -7*(usageWeeks-1-i)).Format(dayLayout)
+		weeks[week] = i
+		u.Weekly = append(u.Weekly, UsagePoint{Week: week})
+	}
+	weeklyUsed := make([]map[int]bool, usageWeeks)
+	usage := make(map[int]*WordUsage)
+	found := make(map[string]*VocabCandidate)
+
+	for _, log := range state.DailyLogs {
+		if !isEssay(log) && !isReading(log) {
+			continue
+		}
+		tokens, sentences := tokenize(logText(log))
+		if len(tokens) == 0 {
+			continue
+		}
+		if isReading(log) {
+			a.candidates(tokens, sentences, log.ID, found)
+			continue
+		}
+
+		start := log.sessionStart(loc)
+		day := start.Format(dayLayout)
+		week, inRange := weeks[start.AddDate(0, 0, -((int(start.Weekday())+6)%7)).Format(dayLayout)]
+		u.Essays++
+		if inRange {
+			u.Weekly[week].Essays++
+			u.Weekly[week].Words += len(tokens)
+		}
+		for idx, n := range a.used(tokens) {
+			if vocab[idx].DateAdded > day {
+				continue
+			}
+			w := usage[idx]
+			if w == nil {
+				w = &WordUsage{ID: vocab[idx].ID, Word: vocab[idx].Word}
+				usage[idx] = w
+			}
+			w.Count += n
+			if day > w.LastUsed {
+				w.LastUsed = day
+			}
+			if inRange {
+				if weeklyUsed[week] == nil {
+					weeklyUsed[week] = make(map[int]bool)
+				}
+				weeklyUsed[week][idx] = true
+			}
+		}
+	}
+
+	for i, used := range weeklyUsed {
+		u.Weekly[i].Used = len(used)
+	}
+	for _, w := range usage {
+		u.Words = append(u.Words, *w)
+	}
+	sort.Slice(u.Words, func(i, j int) bool {
+		if u.Words[i].Count != u.Words[j].Count {
+			return u.Words[i].Count > u.Words[j].Count
+		}
+		return u.Words[i].Word < u.Words[j].Word
+	})
+	u.Used = len(u.Words)
+	if u.Saved > 0 {
+		u.Percent = float64(u.Used) * 100 / float64(u.Saved)
+	}
+	u.Candidates = sortedCandidates(found)
+	return u
+}
+
+// AnalyzeLogVocabulary finds the saved words in a session log's text and,
+// for reading and listening logs, advanced words worth saving.
+func (a *App) AnalyzeLogVocabulary(id string) (LogVocabulary, error) {
+	log, err := a.state.GetLog(id)
+	if errors.Is(err, ErrNotFound) {
+		return LogVocabulary{}, fmt.Errorf("session log %s not found", id)
+	}
+	if err != nil {
+		return LogVocabulary{}, err
+	}
+	state, err := a.state.LoadState()
+	if err != nil {
+		return LogVocabulary{}, err
+	}
+	return analyzeLogVocabulary(log, state.Vocabulary, a.dictionary), nil
+}
+
+// GetVocabularyUsage reports how many saved words were used in essays,
+// week by week, with candidates from reading and listening logs.
+func (a *App) GetVocabularyUsage() (VocabularyUsage, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return VocabularyUsage{}, err
+	}
+	return vocabularyUsage(state, a.dictionary, time.Now()), nil
+}
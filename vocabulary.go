@@ -0,0 +1,380 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VocabPatch lists the fields UpdateVocabulary may change. Nil fields are
+// left as they are.
+type VocabPatch struct {
+	Word      *string   `json:"word,omitempty"`
+	Def       *string   `json:"def,omitempty"`
+	Sentences *string   `json:"sentences,omitempty"`
+	Topic     *string   `json:"topic,omitempty"`
+	Tags      *[]string `json:"tags,omitempty"`
+}
+
+func (p VocabPatch) apply(item *VocabItem) error {
+	if p.Word != nil {
+		word := strings.TrimSpace(*p.Word)
+		if word == "" {
+			return &ValidationError{Field: "word", Message: "is required"}
+		}
+		item.Word = word
+	}
+	if p.Def != nil {
+		item.Def = *p.Def
+	}
+	if p.Sentences != nil {
+		item.Sentences = *p.Sentences
+	}
+	if p.Topic != nil {
+		item.Topic = normalizeTopic(*p.Topic)
+	}
+	if p.Tags != nil {
+		item.Tags = normalizeTags(*p.Tags)
+	}
+	return nil
+}
+
+// VocabAddResult is what AddVocabulary did with a word.
+type VocabAddResult struct {
+	Item   VocabItem `json:"item"`
+	Merged bool      `json:"merged"` // The word was already saved and the new entry was merged into it
+}
+
+// VocabDuplicates is a group of vocabulary items saving the same word.
+type VocabDuplicates struct {
+	Word  string      `json:"word"`  // The word of the oldest item
+	Items []VocabItem `json:"items"` // Oldest first
+}
+
+// VocabBulkResult reports what a bulk operation did.
+type VocabBulkResult struct {
+	Deleted int      `json:"deleted"` // Items removed, by deletion or by merging into another
+	Merged  int      `json:"merged"`  // Items that absorbed others
+	Missing []string `json:"missing"` // IDs not found
+}
+
+// wordIndex finds vocabulary words that are the same word as another,
+// ignoring case and inflection: they are equal or share a dictionary lemma
+// ("analyses" and "analysis", "Policy" and "policies"). Words the
+// dictionary does not know only match themselves. Each word is looked up
+// once, when it is added or searched for.
+type wordIndex struct {
+	dict   *Dictionary
+	keys   map[string]int // The words themselves, to the position first added
+	lemmas map[string]int // Their dictionary lemmas, likewise
+	n      int
+}
+
+func (d *Dictionary) newWordIndex() *wordIndex {
+	return &wordIndex{dict: d, keys: make(map[string]int), lemmas: make(map[string]int)}
+}
+
+// add adds word at the next position, counting from 0.
+func (x *wordIndex) add(word string) {
+	if _, ok := x.keys[dictionaryKey(word)]; !ok {
+		x.keys[dictionaryKey(word)] = x.n
+	}
+	for _, l := range x.dict.lemmas(word) {
+		if _, ok := x.lemmas[l]; !ok {
+			x.lemmas[l] = x.n
+		}
+	}
+	x.n++
+}
+
+// find returns the position of the first word added that is the same word
+// as word, or -1.
+func (x *wordIndex) find(word string) int {
+	first := -1
+	if i, ok := x.keys[dictionaryKey(word)]; ok {
+		first = i
+	}
+	for _, l := range x.dict.lemmas(word) {
+		if i, ok := x.lemmas[l]; ok && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	return first
+}
+
+// has reports whether any word added is the same word as word.
+func (x *wordIndex) has(word string) bool {
+	return x.find(word) >= 0
+}
+
+// findVocab returns the index of the first item saving the same word, or -1.
+func (d *Dictionary) findVocab(vocab []VocabItem, word string) int {
+	x := d.newWordIndex()
+	for _, item := range vocab {
+		x.add(item.Word)
+	}
+	return x.find(word)
+}
+
+// mergeVocab folds from into into: sentences into does not have yet are
+// appended, an empty definition or topic is filled and the tags joined.
+// The word, ID and review schedule of into are kept.
+func mergeVocab(into *VocabItem, from VocabItem) {
+	if strings.TrimSpace(into.Def) == "" {
+		into.Def = from.Def
+	}
+	if into.Topic == "" {
+		into.Topic = from.Topic
+	}
+	into.Tags = normalizeTags(append(append([]string(nil), into.Tags...), from.Tags...))
+
+	lines := strings.Split(into.Sentences, "\n")
+	seen := make(map[string]bool)
+	for _, l := range lines {
+		seen[strings.ToLower(strings.TrimSpace(l))] = true
+	}
+	for _, l := range strings.Split(from.Sentences, "\n") {
+		if key := strings.ToLower(strings.TrimSpace(l)); key != "" && !seen[key] {
+			seen[key] = true
+			lines = append(lines, l)
+		}
+	}
+	into.Sentences = strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// duplicateGroups groups the vocabulary by word, keeping groups of two or
+// more, each oldest first as saved. An item joins the group of the oldest
+// item saving the same word that does not itself belong to an older group.
+func (d *Dictionary) duplicateGroups(vocab []VocabItem) []VocabDuplicates {
+	var all []VocabDuplicates
+	oldest := d.newWordIndex() // The first item of each group, in order
+	for _, item := range vocab {
+		if g := oldest.find(item.Word); g >= 0 {
+			all[g].Items = append(all[g].Items, item)
+			continue
+		}
+		oldest.add(item.Word)
+		all = append(all, VocabDuplicates{Word: item.Word, Items: []VocabItem{item}})
+	}
+	groups := []VocabDuplicates{}
+	for _, g := range all {
+		if len(g.Items) > 1 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// mergeInto merges the items with ids into the item with target, deleting
+// them, within StateService.Do. It returns the merged item.
+func mergeInto(store Store, vocab []VocabItem, target string, ids []string, result *VocabBulkResult) (VocabItem, error) {
+	var merged VocabItem
+	var from []VocabItem
+	for _, id := range ids {
+		i := vocabIndex(vocab, id)
+		if i < 0 {
+			result.Missing = append(result.Missing, id)
+			continue
+		}
+		from = append(from, vocab[i])
+	}
+	err := store.UpdateVocab(target, func(item *VocabItem) error {
+		for _, f := range from {
+			mergeVocab(item, f)
+		}
+		merged = *item
+		return nil
+	})
+	if err != nil || len(from) == 0 {
+		return merged, err
+	}
+	result.Merged++
+	for _, f := range from {
+		deleted, err := store.DeleteVocab(f.ID)
+		if err != nil {
+			return merged, err
+		}
+		if deleted {
+			result.Deleted++
+		}
+	}
+	return merged, nil
+}
+
+func vocabIndex(vocab []VocabItem, id string) int {
+	for i, item := range vocab {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddVocabulary saves a word to the forge. An empty topic is guessed from
+// the embedded topic word lists. A word already saved, in any case or
+// inflection, is not added again: the new definition, sentences and tags
+// are merged into the saved entry instead.
+func (a *App) AddVocabulary(word string, def string, sentences string, topic string, tags []string) (VocabAddResult, error) {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return VocabAddResult{}, &ValidationError{Field: "word", Message: "is required"}
+	}
+	var result VocabAddResult
+	err := a.state.Do(func(store Store) error {
+		state, err := store.LoadState()
+		if err != nil {
+			return err
+		}
+		now := time.Now().In(state.UserProfile.location())
+		item := VocabItem{
+			ID:        fmt.Sprintf("%d", now.UnixNano()),
+			Word:      word,
+			Def:       def,
+			Sentences: sentences,
+			DateAdded: now.Format(dayLayout),
+			Time:      now.Format("15:04"),
+			Topic:     normalizeTopic(topic),
+			Tags:      normalizeTags(tags),
+		}
+		if i := a.dictionary.findVocab(state.Vocabulary, word); i >= 0 {
+			result.Merged = true
+			return store.UpdateVocab(state.Vocabulary[i].ID, func(saved *VocabItem) error {
+				mergeVocab(saved, item)
+				result.Item = *saved
+				return nil
+			})
+		}
+		if item.Topic == "" {
+			item.Topic = a.dictionary.guessTopic(word)
+		}
+		result.Item = item
+		return store.AddVocab(item)
+	})
+	return result, err
+}
+
+// UpdateVocabulary applies patch to the vocabulary item with the given ID
+// and returns the updated item. Renaming it to a word saved in another
+// entry is refused; merge the two instead.
+func (a *App) UpdateVocabulary(id string, patch VocabPatch) (VocabItem, error) {
+	var updated VocabItem
+	err := a.state.Do(func(store Store) error {
+		if patch.Word != nil {
+			state, err := store.LoadState()
+			if err != nil {
+				return err
+			}
+			var others []VocabItem
+			for _, item := range state.Vocabulary {
+				if item.ID != id {
+					others = append(others, item)
+				}
+			}
+			if i := a.dictionary.findVocab(others, *patch.Word); i >= 0 {
+				return &ValidationError{Field: "word", Message: fmt.Sprintf("%q is already saved as %q", *patch.Word, others[i].Word)}
+			}
+		}
+		return store.UpdateVocab(id, func(item *VocabItem) error {
+			if err := patch.apply(item); err != nil {
+				return err
+			}
+			updated = *item
+			return nil
+		})
+	})
+	if errors.Is(err, ErrNotFound) {
+		return VocabItem{}, fmt.Errorf("vocabulary item %s not found", id)
+	}
+	return updated, err
+}
+
+// DeleteVocabulary removes a vocabulary item. It reports whether the item
+// existed.
+func (a *App) DeleteVocabulary(id string) (bool, error) {
+	return a.state.DeleteVocab(id)
+}
+
+// DeleteVocabularies removes the vocabulary items with the given IDs.
+func (a *App) DeleteVocabularies(ids []string) (VocabBulkResult, error) {
+	result := VocabBulkResult{Missing: []string{}}
+	err := a.state.Do(func(store Store) error {
+		for _, id := range ids {
+			deleted, err := store.DeleteVocab(id)
+			if err != nil {
+				return err
+			}
+			if deleted {
+				result.Deleted++
+			} else {
+				result.Missing = append(result.Missing, id)
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// FindDuplicateVocabulary lists the words saved more than once, ignoring
+// case and inflection.
+func (a *App) FindDuplicateVocabulary() ([]VocabDuplicates, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return a.dictionary.duplicateGroups(state.Vocabulary), nil
+}
+
+// MergeVocabulary merges the vocabulary items with the given IDs into the
+// first one, which keeps its word and review schedule, and returns it.
+// Repeated IDs are merged once.
+func (a *App) MergeVocabulary(ids []string) (VocabItem, error) {
+	var unique []string
+	for _, id := range ids {
+		if !contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) < 2 {
+		return VocabItem{}, &ValidationError{Field: "ids", Message: "choose at least two entries to merge"}
+	}
+	ids = unique
+	var merged VocabItem
+	err := a.state.Do(func(store Store) error {
+		state, err := store.LoadState()
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if vocabIndex(state.Vocabulary, id) < 0 {
+				return fmt.Errorf("vocabulary item %s not found", id)
+			}
+		}
+		merged, err = mergeInto(store, state.Vocabulary, ids[0], ids[1:], &VocabBulkResult{})
+		return err
+	})
+	return merged, err
+}
+
+// MergeDuplicateVocabulary merges every group FindDuplicateVocabulary
+// reports into its oldest entry.
+func (a *App) MergeDuplicateVocabulary() (VocabBulkResult, error) {
+	result := VocabBulkResult{Missing: []string{}}
+	err := a.state.Do(func(store Store) error {
+		state, err := store.LoadState()
+		if err != nil {
+			return err
+		}
+		for _, g := range a.dictionary.duplicateGroups(state.Vocabulary) {
+			var ids []string
+			for _, item := range g.Items[1:] {
+				ids = append(ids, item.ID)
+			}
+			if _, err := mergeInto(store, state.Vocabulary, g.Items[0].ID, ids, &result); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDuplicateGroups(t *testing.T) {
+	d := NewDictionary(t.TempDir())
+	var vocab []VocabItem
+	for i, w := range []string{"analysis", "letter", "Policy", "let", "analyses", "policies", "butter", "but", "ANALYSIS"} {
+		vocab = append(vocab, VocabItem{ID: string(rune('a' + i)), Word: w})
+	}
+	var got []string
+	for _, g := range d.duplicateGroups(vocab) {
+		var words []string
+		for _, item := range g.Items {
+			words = append(words, item.Word)
+		}
+		got = append(got, strings.Join(words, ","))
+	}
+	want := []string{"analysis,analyses,ANALYSIS", "Policy,policies"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got groups %q, want %q", got, want)
+	}
+}
+
+func TestFindVocab(t *testing.T) {
+	d := NewDictionary(t.TempDir())
+	vocab := []VocabItem{{Word: "letter"}, {Word: "policies"}, {Word: "Policy"}}
+	for word, want := range map[string]int{"policy": 1, "POLICIES": 1, "let": -1, "Letter": 0, "plan": -1} {
+		if got := d.findVocab(vocab, word); got != want {
+			t.Errorf("findVocab(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
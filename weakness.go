@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"Engress/scoring"
+)
+
+// How much each signal contributes to a skill's weakness score.
+const (
+	weightScoreGap = 0.4
+	weightMinutes  = 0.25
+	weightRecency  = 0.2
+	weightTrend    = 0.15
+)
+
+const (
+	weaknessWindowDays = 14 // Minutes are compared over this many days
+	trendWindow        = 5  // Latest scores the trend is measured over
+)
+
+// Weakness signal keys, which briefing catalogs word as "reason.<key>".
+const (
+	SignalNoScore        = "no_score"
+	SignalScoreGap       = "score_gap"
+	SignalFewMinutes     = "few_minutes"
+	SignalNotPractised   = "not_practised"
+	SignalNeverPractised = "never_practised"
+	SignalScoresFalling  = "scores_falling"
+)
+
+// WeaknessSignal is one reason a skill ranks weak, with the numbers behind
+// it, so briefings can word it in their own language.
+type WeaknessSignal struct {
+	Key     string  `json:"key"`
+	Score   float64 `json:"score,omitempty"`   // score_gap: the recent score; scores_falling: the latest
+	From    float64 `json:"from,omitempty"`    // scores_falling: the earliest score of the trend
+	Target  float64 `json:"target,omitempty"`  // score_gap
+	Gap     float64 `json:"gap,omitempty"`     // score_gap
+	Minutes int     `json:"minutes,omitempty"` // few_minutes: the skill's minutes
+	Total   int     `json:"total,omitempty"`   // few_minutes: all minutes
+	Days    int     `json:"days,omitempty"`    // few_minutes: the window; not_practised: days since
+}
+
+// String words the signal in English, as shown in Weakness.Reasons.
+func (s WeaknessSignal) String() string {
+	switch s.Key {
+	case SignalNoScore:
+		return "No score logged yet"
+	case SignalScoreGap:
+		return fmt.Sprintf("Recent score %g is %g below the %g target", s.Score, s.Gap, s.Target)
+	case SignalFewMinutes:
+		return fmt.Sprintf("Only %d of %d %s in the last %d days", s.Minutes, s.Total, plural(s.Total, "minute", "minutes"), s.Days)
+	case SignalNotPractised:
+		return fmt.Sprintf("Not practised for %d days", s.Days)
+	case SignalNeverPractised:
+		return "Never practised"
+	case SignalScoresFalling:
+		return fmt.Sprintf("Scores falling (%g to %g)", s.From, s.Score)
+	}
+	return s.Key
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// Weakness is one skill's place in the weakness ranking. Score runs from
+// 0 (nothing to worry about) to 1 (weakest possible), and Reasons explain
+// the signals that raised it, in English and as Signals.
+type Weakness struct {
+	Module  string           `json:"module"`
+	Score   float64          `json:"score"`
+	Reasons []string         `json:"reasons"`
+	Signals []WeaknessSignal `json:"signals"`
+
+	RecentScore   float64 `json:"recent_score"`    // 0 if never scored
+	SectionTarget float64 `json:"section_target"`  // Target on the section's scale
+	Minutes       int     `json:"minutes"`         // Logged in the last weaknessWindowDays
+	DaysSinceLast int     `json:"days_since_last"` // -1 if never practised
+	Trend         float64 `json:"trend"`           // Change across the latest scores
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// title capitalizes a lowercase module name for display.
+func title(module string) string {
+	if module == "" {
+		return module
+	}
+	return strings.ToUpper(module[:1]) + module[1:]
+}
+
+func (w *Weakness) signal(s WeaknessSignal) {
+	w.Signals = append(w.Signals, s)
+	w.Reasons = append(w.Reasons, s.String())
+}
+
+// rankWeaknesses scores each skill on its gap to the target, its share of
+// recent study time, how long it has been neglected and whether its scores
+// are falling, and returns the skills weakest first.
+func rankWeaknesses(logs []DailyLog, profile UserProfile, now time.Time) []Weakness {
+	loc := profile.location()
+	now = now.In(loc)
+	today := now.Format(dayLayout)
+	windowStart := now.AddDate(0, 0, -weaknessWindowDays+1).Format(dayLayout)
+
+	exam := scoring.MustLookup(profile.ExamType)
+	target := profile.TargetScore
+	if exam.Total {
+		target /= float64(len(exam.Sections))
+	}
+	history := buildScoreHistory(logs, profile)
+
+	minutes := make(map[string]int, len(skills))
+	lastDay := make(map[string]string, len(skills))
+	total := 0
+	for _, log := range logs {
+		module, day := strings.ToLower(log.Module), log.day(loc)
+		if day > lastDay[module] {
+			lastDay[module] = day
+		}
+		if day >= windowStart && day <= today {
+			minutes[module] += log.Duration
+			total += log.Duration
+		}
+	}
+
+	ranking := make([]Weakness, 0, len(skills))
+	for _, skill := range skills {
+		scale := exam.ScaleFor(skill)
+		// One tenth of the scale is roughly one IELTS band
+		unit := (scale.Max - scale.Min) / 10
+		w := Weakness{
+			Module:        skill,
+			Reasons:       []string{},
+			Signals:       []WeaknessSignal{},
+			RecentScore:   history.Recent.get(skill),
+			SectionTarget: scale.Round(target),
+			Minutes:       minutes[skill],
+			DaysSinceLast: -1,
+		}
+
+		// Score gap: two units below target is as weak as it gets
+		gap := 0.5
+		if w.RecentScore == 0 {
+			w.signal(WeaknessSignal{Key: SignalNoScore})
+		} else {
+			gap = clamp01((w.SectionTarget - w.RecentScore) / unit / 2)
+			if w.RecentScore < w.SectionTarget {
+				w.signal(WeaknessSignal{Key: SignalScoreGap, Score: w.RecentScore, Target: w.SectionTarget, Gap: math.Round((w.SectionTarget-w.RecentScore)*100) / 100})
+			}
+		}
+
+		// Time: falling short of an even share of recent study minutes
+		share := 1.0
+		fairShare := 1 / float64(len(skills))
+		if total > 0 {
+			share = clamp01((fairShare - float64(w.Minutes)/float64(total)) / fairShare)
+		}
+		if share > 0.5 {
+			w.signal(WeaknessSignal{Key: SignalFewMinutes, Minutes: w.Minutes, Total: total, Days: weaknessWindowDays})
+		}
+
+		// Recency: two weeks without practice is as neglected as it gets
+		recency := 1.0
+		if last, err := time.ParseInLocation(dayLayout, lastDay[skill], loc); err == nil {
+			todayStart, _ := time.ParseInLocation(dayLayout, today, loc)
+			w.DaysSinceLast = int(math.Round(todayStart.Sub(last).Hours() / 24))
+			recency = clamp01(float64(w.DaysSinceLast) / weaknessWindowDays)
+			if w.DaysSinceLast >= 3 {
+				w.signal(WeaknessSignal{Key: SignalNotPractised, Days: w.DaysSinceLast})
+			}
+		} else {
+			w.signal(WeaknessSignal{Key: SignalNeverPractised})
+		}
+
+		// Trend: a drop of one unit over the latest scores is as bad as it gets
+		trend := 0.0
+		if series := *history.series(skill); len(series) >= 2 {
+			if len(series) > trendWindow {
+				series = series[len(series)-trendWindow:]
+			}
+			first, last := series[0].Score, series[len(series)-1].Score
+			w.Trend = last - first
+			trend = clamp01(-w.Trend / unit)
+			if w.Trend < 0 {
+				w.signal(WeaknessSignal{Key: SignalScoresFalling, From: first, Score: last})
+			}
+		}
+
+		w.Score = weightScoreGap*gap + weightMinutes*share + weightRecency*recency + weightTrend*trend
+		ranking = append(ranking, w)
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].Score > ranking[j].Score
+	})
+	return ranking
+}
+
+// GetWeaknesses returns the four skills ranked weakest first, with reasons.
+func (a *App) GetWeaknesses() ([]Weakness, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return rankWeaknesses(state.DailyLogs, state.UserProfile, time.Now()), nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestRankWeaknesses(t *testing.T) {
+	state := fixtureState()
+	ranking := rankWeaknesses(state.DailyLogs, state.UserProfile, fixtureNow)
+	if len(ranking) != 4 {
+		t.Fatalf("got %d skills, want 4", len(ranking))
+	}
+	for _, w := range ranking[:2] {
+		if w.Module != "listening" && w.Module != "speaking" {
+			t.Errorf("%s ranked above a skill never practised", w.Module)
+		}
+		never := false
+		for _, s := range w.Signals {
+			never = never || s.Key == SignalNeverPractised
+		}
+		if w.DaysSinceLast != -1 || !never {
+			t.Errorf("%s: got %+v, want never practised", w.Module, w)
+		}
+	}
+	for _, w := range ranking {
+		if w.Module == "writing" && (w.RecentScore == 0 || w.Minutes != 85 || w.DaysSinceLast != 0) {
+			t.Errorf("writing: got %+v, want a recent score, 85 minutes, practised today", w)
+		}
+	}
+}
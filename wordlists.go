@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed wordlists
+var embeddedWordLists embed.FS
+
+// Word list kinds.
+const (
+	ListAWL   = "awl"
+	ListTopic = "topic"
+)
+
+// suggestionsPerList is how many unlearned words coverage suggests per list.
+const suggestionsPerList = 5
+
+// WordList is an embedded list of words to learn: an Academic Word List
+// sublist or an IELTS topic.
+type WordList struct {
+	ID    string   `json:"id"` // "awl-1" ... "awl-10", "topic-environment", ...
+	Name  string   `json:"name"`
+	Kind  string   `json:"kind"` // "awl" or "topic"
+	Words []string `json:"words"`
+}
+
+// readWordListTSV calls fn with the columns of each line of an embedded
+// tab-separated word list that has at least two.
+func readWordListTSV(name string, fn func(cols []string)) {
+	f, err := embeddedWordLists.Open(name)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" && !strings.HasPrefix(line, "#") {
+			if cols := strings.Split(line, "\t"); len(cols) >= 2 {
+				fn(cols)
+			}
+		}
+	}
+}
+
+// loadWordLists reads wordlists/awl.tsv (headword, sublist) and
+// wordlists/topics.tsv (topic, word), keeping the order of the files.
+var loadWordLists = sync.OnceValue(func() []WordList {
+	var lists []WordList
+	index := make(map[string]int)
+	add := func(id, name, kind, word string) {
+		i, ok := index[id]
+		if !ok {
+			i = len(lists)
+			index[id] = i
+			lists = append(lists, WordList{ID: id, Name: name, Kind: kind})
+		}
+		lists[i].Words = append(lists[i].Words, dictionaryKey(word))
+	}
+	readWordListTSV("wordlists/awl.tsv", func(cols []string) {
+		n, _ := strconv.Atoi(cols[1])
+		add(fmt.Sprintf("awl-%d", n), fmt.Sprintf("AWL Sublist %d", n), ListAWL, cols[0])
+	})
+	readWordListTSV("wordlists/topics.tsv", func(cols []string) {
+		add("topic-"+cols[0], title(cols[0]), ListTopic, cols[1])
+	})
+	return lists
+})
+
+// loadAWLFamilies reads the word family members of each AWL headword from
+// the third column of wordlists/awl.tsv.
+var loadAWLFamilies = sync.OnceValue(func() map[string]map[string]bool {
+	families := make(map[string]map[string]bool)
+	readWordListTSV("wordlists/awl.tsv", func(cols []string) {
+		family := make(map[string]bool)
+		if len(cols) > 2 {
+			for _, member := range strings.Split(cols[2], ",") {
+				if member = dictionaryKey(member); member != "" {
+					family[member] = true
+				}
+			}
+		}
+		families[dictionaryKey(cols[0])] = family
+	})
+	return families
+})
+
+// topicNames lists the embedded topics, in file order.
+func topicNames() []string {
+	var out []string
+	for _, l := range loadWordLists() {
+		if l.Kind == ListTopic {
+			out = append(out, strings.TrimPrefix(l.ID, "topic-"))
+		}
+	}
+	return out
+}
+
+// loadFamilyIndex maps every list word, and every member Coxhead lists for
+// an AWL headword, to the list words whose family it is in.
+var loadFamilyIndex = sync.OnceValue(func() map[string][]string {
+	index := make(map[string][]string)
+	add := func(form, listWord string) {
+		if !contains(index[form], listWord) {
+			index[form] = append(index[form], listWord)
+		}
+	}
+	families := loadAWLFamilies()
+	for _, l := range loadWordLists() {
+		for _, w := range l.Words {
+			add(w, w)
+			for member := range families[w] {
+				add(member, w)
+			}
+		}
+	}
+	return index
+})
+
+// families returns the list words whose family word belongs to: words it
+// is, or inflects, or for AWL headwords one of the family members, so
+// "analyses" and "analytical" are in the family of "analyse" but "summer"
+// is not in that of "sum".
+func (d *Dictionary) families(word string) map[string]bool {
+	index := loadFamilyIndex()
+	out := make(map[string]bool)
+	for _, form := range d.stems(word) {
+		for _, w := range index[form] {
+			out[w] = true
+		}
+	}
+	return out
+}
+
+// normalizeTopic lowercases a topic and trims its spaces.
+func normalizeTopic(topic string) string {
+	return strings.ToLower(strings.TrimSpace(topic))
+}
+
+// normalizeTags splits tags on commas, semicolons and spaces, lowercases
+// them and drops duplicates.
+func normalizeTags(tags []string) []string {
+	out := []string{}
+	for _, t := range tags {
+		for _, tag := range strings.FieldsFunc(strings.ToLower(t), func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t'
+		}) {
+			if !contains(out, tag) {
+				out = append(out, tag)
+			}
+		}
+	}
+	return out
+}
+
+// guessTopic returns the first embedded topic listing word, or "".
+func (d *Dictionary) guessTopic(word string) string {
+	families := d.families(word)
+	for _, l := range loadWordLists() {
+		if l.Kind != ListTopic {
+			continue
+		}
+		for _, w := range l.Words {
+			if families[w] {
+				return strings.TrimPrefix(l.ID, "topic-")
+			}
+		}
+	}
+	return ""
+}
+
+// WordSuggestion is a list word not yet in the vocabulary.
+type WordSuggestion struct {
+	Word string `json:"word"`
+	Def  string `json:"def"` // From the offline dictionary; empty if not found
+}
+
+// ListCoverage is how much of one word list the vocabulary covers. A word
+// is covered once it is in the vocabulary, and learned once its review
+// interval reaches matureInterval days.
+type ListCoverage struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Kind        string           `json:"kind"`
+	Total       int              `json:"total"`
+	Covered     int              `json:"covered"`
+	Learned     int              `json:"learned"`
+	Percent     float64          `json:"percent"` // Covered as a percentage of Total
+	Suggestions []WordSuggestion `json:"suggestions"`
+}
+
+// TagCount is how many vocabulary items have a topic or tag.
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// VocabularyCoverage reports word list coverage and how the vocabulary is
+// grouped.
+type VocabularyCoverage struct {
+	AWLTotal   int            `json:"awl_total"`
+	AWLCovered int            `json:"awl_covered"`
+	AWLPercent float64        `json:"awl_percent"`
+	Lists      []ListCoverage `json:"lists"`
+	Topics     []TagCount     `json:"topics"` // Items per topic, most first
+	Tags       []TagCount     `json:"tags"`   // Items per tag, most first
+}
+
+func vocabularyCoverage(vocab []VocabItem, d *Dictionary) VocabularyCoverage {
+	c := VocabularyCoverage{Lists: []ListCoverage{}}
+	// Each item is looked up once, not once per list word
+	covers, learns := make(map[string]bool), make(map[string]bool)
+	for _, item := range vocab {
+		for w := range d.families(item.Word) {
+			covers[w] = true
+			learns[w] = learns[w] || item.Review.Interval >= matureInterval
+		}
+	}
+	for _, l := range loadWordLists() {
+		lc := ListCoverage{ID: l.ID, Name: l.Name, Kind: l.Kind, Total: len(l.Words), Suggestions: []WordSuggestion{}}
+		for _, w := range l.Words {
+			covered, learned := covers[w], learns[w]
+			if covered {
+				lc.Covered++
+			}
+			if learned {
+				lc.Learned++
+			}
+			if !covered && len(lc.Suggestions) < suggestionsPerList {
+				word := strings.ReplaceAll(w, "_", " ")
+				lc.Suggestions = append(lc.Suggestions, WordSuggestion{Word: word, Def: d.Lookup(word).Def})
+			}
+		}
+		if lc.Total > 0 {
+			lc.Percent = float64(lc.Covered) * 100 / float64(lc.Total)
+		}
+		if l.Kind == ListAWL {
+			c.AWLTotal += lc.Total
+			c.AWLCovered += lc.Covered
+		}
+		c.Lists = append(c.Lists, lc)
+	}
+	if c.AWLTotal > 0 {
+		c.AWLPercent = float64(c.AWLCovered) * 100 / float64(c.AWLTotal)
+	}
+
+	topics, tags := make(map[string]int), make(map[string]int)
+	for _, item := range vocab {
+		if item.Topic != "" {
+			topics[item.Topic]++
+		}
+		for _, tag := range item.Tags {
+			tags[tag]++
+		}
+	}
+	c.Topics, c.Tags = sortedCounts(topics), sortedCounts(tags)
+	return c
+}
+
+func sortedCounts(counts map[string]int) []TagCount {
+	out := make([]TagCount, 0, len(counts))
+	for name, n := range counts {
+		out = append(out, TagCount{Name: name, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// GetVocabularyCoverage reports Academic Word List and topic coverage,
+// with suggestions of words to add next.
+func (a *App) GetVocabularyCoverage() (VocabularyCoverage, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return VocabularyCoverage{}, err
+	}
+	return vocabularyCoverage(state.Vocabulary, a.dictionary), nil
+}
+
+// GetTopics lists the embedded topics followed by any others in use.
+func (a *App) GetTopics() ([]string, error) {
+	state, err := a.state.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	topics := topicNames()
+	var custom []string
+	for _, item := range state.Vocabulary {
+		if item.Topic != "" && !contains(topics, item.Topic) && !contains(custom, item.Topic) {
+			custom = append(custom, item.Topic)
+		}
+	}
+	sort.Strings(custom)
+	return append(topics, custom...), nil
+}
+
+// SetVocabularyTopic sets the topic and tags of a vocabulary item.
+func (a *App) SetVocabularyTopic(id string, topic string, tags []string) error {
+	return a.state.UpdateVocab(id, func(item *VocabItem) error {
+		item.Topic = normalizeTopic(topic)
+		item.Tags = normalizeTags(tags)
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestVocabularyCoverageFamilies(t *testing.T) {
+	d := NewDictionary(t.TempDir())
+	vocab := []VocabItem{
+		{ID: "1", Word: "analyses", Review: ReviewState{Interval: matureInterval}},
+		{ID: "2", Word: "Environmental"},
+		{ID: "3", Word: "summer"},
+	}
+	c := vocabularyCoverage(vocab, d)
+	var sublist1, sublist4 ListCoverage
+	for _, l := range c.Lists {
+		switch l.ID {
+		case "awl-1":
+			sublist1 = l
+		case "awl-4":
+			sublist4 = l
+		}
+	}
+	// analyse (learned through "analysis") and environment
+	if sublist1.Covered != 2 || sublist1.Learned != 1 {
+		t.Errorf("sublist 1: %d covered, %d learned, want 2 and 1", sublist1.Covered, sublist1.Learned)
+	}
+	// "summer" is not in the family of "sum"
+	if sublist4.Covered != 0 {
+		t.Errorf("sublist 4: %d covered, want 0", sublist4.Covered)
+	}
+	if c.AWLCovered != 2 {
+		t.Errorf("%d AWL words covered, want 2", c.AWLCovered)
+	}
+}
+
+func TestGuessTopic(t *testing.T) {
+	d := NewDictionary(t.TempDir())
+	for word, want := range map[string]string{"pollution": "environment", "Climates": "environment", "zebra": ""} {
+		if got := d.guessTopic(word); got != want {
+			t.Errorf("guessTopic(%q) = %q, want %q", word, got, want)
+		}
+	}
+}